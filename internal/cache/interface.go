@@ -4,7 +4,9 @@ package cache
 type CacheServiceInterface interface {
 	Set(key string, value interface{}) error
 	SetWithTTL(key string, value interface{}, ttlSeconds int) error
+	SetNX(key string, value interface{}, ttlSeconds int) (bool, error)
 	Get(key string, dest interface{}) error
 	Delete(key string) error
+	DeleteByPrefix(prefix string) error
 	Close() error
 }
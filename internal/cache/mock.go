@@ -1,9 +1,17 @@
 package cache
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
 
-// MockCacheService is a mock implementation of CacheService for testing
+// MockCacheService is a mock implementation of CacheService for testing.
+// It is also used as an in-memory fallback when Redis is unavailable, so it
+// is safe for concurrent use by request handlers and background jobs.
 type MockCacheService struct {
+	mu   sync.RWMutex
 	data map[string]interface{}
 }
 
@@ -16,40 +24,74 @@ func NewMockCacheService() *MockCacheService {
 
 // Set stores a value in the mock cache
 func (m *MockCacheService) Set(key string, value interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.data[key] = value
 	return nil
 }
 
 // SetWithTTL stores a value in the mock cache with TTL (ignored in mock)
 func (m *MockCacheService) SetWithTTL(key string, value interface{}, ttlSeconds int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.data[key] = value
 	return nil
 }
 
+// SetNX stores a value in the mock cache unless the key exists, reporting
+// whether it was stored (TTL ignored in mock)
+func (m *MockCacheService) SetNX(key string, value interface{}, ttlSeconds int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.data[key]; exists {
+		return false, nil
+	}
+	m.data[key] = value
+	return true, nil
+}
+
 // Get retrieves a value from the mock cache
 func (m *MockCacheService) Get(key string, dest interface{}) error {
+	m.mu.RLock()
 	value, exists := m.data[key]
+	m.mu.RUnlock()
 	if !exists {
 		return fmt.Errorf("key not found")
 	}
 
-	// Simple type assertion for testing
-	if destPtr, ok := dest.(*[]interface{}); ok {
-		if val, ok := value.([]interface{}); ok {
-			*destPtr = val
-			return nil
-		}
+	// Round-trip through JSON so the mock behaves like the Redis implementation
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonData, dest); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
 	}
 
-	return fmt.Errorf("type assertion failed")
+	return nil
 }
 
 // Delete removes a key from the mock cache
 func (m *MockCacheService) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	delete(m.data, key)
 	return nil
 }
 
+// DeleteByPrefix removes every key starting with prefix from the mock cache
+func (m *MockCacheService) DeleteByPrefix(prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.data {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.data, key)
+		}
+	}
+	return nil
+}
+
 // Close closes the mock cache (no-op)
 func (m *MockCacheService) Close() error {
 	return nil
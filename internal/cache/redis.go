@@ -74,6 +74,22 @@ func (c *CacheService) SetWithTTL(key string, value interface{}, ttlSeconds int)
 	return nil
 }
 
+// SetNX atomically stores a value in cache with custom TTL unless the key
+// exists, reporting whether it was stored
+func (c *CacheService) SetNX(key string, value interface{}, ttlSeconds int) (bool, error) {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	stored, err := c.client.SetNX(c.ctx, key, jsonData, time.Duration(ttlSeconds)*time.Second).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to set cache: %w", err)
+	}
+
+	return stored, nil
+}
+
 // Get retrieves a value from cache
 func (c *CacheService) Get(key string, dest interface{}) error {
 	val, err := c.client.Get(c.ctx, key).Result()
@@ -101,6 +117,20 @@ func (c *CacheService) Delete(key string) error {
 	return nil
 }
 
+// DeleteByPrefix removes every key starting with prefix from cache
+func (c *CacheService) DeleteByPrefix(prefix string) error {
+	iter := c.client.Scan(c.ctx, 0, prefix+"*", 100).Iterator()
+	for iter.Next(c.ctx) {
+		if err := c.client.Del(c.ctx, iter.Val()).Err(); err != nil {
+			return fmt.Errorf("failed to delete from cache: %w", err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan cache keys: %w", err)
+	}
+	return nil
+}
+
 // Close closes the Redis connection
 func (c *CacheService) Close() error {
 	return c.client.Close()
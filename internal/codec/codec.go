@@ -0,0 +1,208 @@
+package codec
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec encodes and decodes values in one wire format. Every format takes its
+// field names from the json struct tags, so a value looks the same in each.
+type Codec struct {
+	name      string
+	mediaType string
+	// aliases are further media types accepted for the format
+	aliases []string
+	// suffix is the structured syntax suffix of RFC 6839, e.g. +json
+	suffix string
+	encode func(w io.Writer, v interface{}) error
+	decode func(r io.Reader, v interface{}) error
+}
+
+// Name returns the name of the format, e.g. JSON
+func (c *Codec) Name() string {
+	return c.name
+}
+
+// MediaType returns the media type responses in the format are labelled with
+func (c *Codec) MediaType() string {
+	return c.mediaType
+}
+
+// Encode writes v to w in the format
+func (c *Codec) Encode(w io.Writer, v interface{}) error {
+	return c.encode(w, v)
+}
+
+// Decode reads a value in the format from r into v
+func (c *Codec) Decode(r io.Reader, v interface{}) error {
+	return c.decode(r, v)
+}
+
+// matches reports how specifically a media range such as application/json,
+// application/* or */* names the format, or -1 when it does not
+func (c *Codec) matches(mediaRange string) int {
+	switch {
+	case mediaRange == "*/*":
+		return 0
+	case mediaRange == "application/*":
+		return 1
+	case mediaRange == c.mediaType:
+		return 2
+	case c.suffix != "" && strings.HasPrefix(mediaRange, "application/") && strings.HasSuffix(mediaRange, c.suffix):
+		return 2
+	}
+	for _, alias := range c.aliases {
+		if mediaRange == alias {
+			return 2
+		}
+	}
+	return -1
+}
+
+var (
+	// JSON is the default format
+	JSON = &Codec{
+		name:      "JSON",
+		mediaType: "application/json",
+		suffix:    "+json",
+		encode: func(w io.Writer, v interface{}) error {
+			return json.NewEncoder(w).Encode(v)
+		},
+		decode: func(r io.Reader, v interface{}) error {
+			return json.NewDecoder(r).Decode(v)
+		},
+	}
+
+	// XML maps JSON objects to elements named after their keys and arrays to
+	// item elements
+	XML = &Codec{
+		name:      "XML",
+		mediaType: "application/xml",
+		aliases:   []string{"text/xml"},
+		suffix:    "+xml",
+		encode:    encodeXML,
+		decode:    decodeXML,
+	}
+
+	// MessagePack encodes timestamps with the MessagePack timestamp extension
+	MessagePack = &Codec{
+		name:      "MessagePack",
+		mediaType: "application/msgpack",
+		aliases:   []string{"application/x-msgpack", "application/vnd.msgpack"},
+		encode: func(w io.Writer, v interface{}) error {
+			encoder := msgpack.NewEncoder(w)
+			encoder.SetCustomStructTag("json")
+			encoder.UseCompactInts(true)
+			return encoder.Encode(v)
+		},
+		decode: func(r io.Reader, v interface{}) error {
+			decoder := msgpack.NewDecoder(r)
+			decoder.SetCustomStructTag("json")
+			return decoder.Decode(v)
+		},
+	}
+
+	// CBOR encodes timestamps as RFC 3339 strings, like JSON
+	CBOR = &Codec{
+		name:      "CBOR",
+		mediaType: "application/cbor",
+		suffix:    "+cbor",
+		encode: func(w io.Writer, v interface{}) error {
+			return cborEncoding.NewEncoder(w).Encode(v)
+		},
+		decode: func(r io.Reader, v interface{}) error {
+			return cborDecoding.NewDecoder(r).Decode(v)
+		},
+	}
+)
+
+// codecs lists every format in order of preference
+var codecs = []*Codec{JSON, XML, MessagePack, CBOR}
+
+var (
+	cborEncoding, _ = cbor.EncOptions{Time: cbor.TimeRFC3339Nano}.EncMode()
+	cborDecoding, _ = cbor.DecOptions{DefaultMapType: reflect.TypeOf(map[string]interface{}(nil))}.DecMode()
+)
+
+// Negotiate picks the format of a response from an Accept header: the
+// acceptable format with the highest quality, the one the client listed
+// first on a tie. Without an Accept header it picks JSON; ok is false when
+// none of the formats is acceptable.
+func Negotiate(accept string) (c *Codec, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		return JSON, true
+	}
+
+	type mediaRange struct {
+		name    string
+		quality float64
+	}
+	var ranges []mediaRange
+	for _, value := range strings.Split(accept, ",") {
+		name, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, exists := params["q"]; exists {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{name: name, quality: quality})
+	}
+
+	var best *Codec
+	bestQuality, bestPosition := 0.0, 0
+	for _, candidate := range codecs {
+		// The most specific range naming the format sets its quality
+		specificity, quality, position := -1, 0.0, 0
+		for i, r := range ranges {
+			if s := candidate.matches(r.name); s > specificity {
+				specificity, quality, position = s, r.quality, i
+			}
+		}
+		if specificity < 0 || quality <= 0 {
+			continue
+		}
+		if best == nil || quality > bestQuality || (quality == bestQuality && position < bestPosition) {
+			best, bestQuality, bestPosition = candidate, quality, position
+		}
+	}
+	return best, best != nil
+}
+
+// ForContentType returns the format of a request body from its Content-Type
+// header. Without a Content-Type it returns JSON; ok is false for a type none
+// of the formats reads.
+func ForContentType(contentType string) (c *Codec, ok bool) {
+	if strings.TrimSpace(contentType) == "" {
+		return JSON, true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	for _, candidate := range codecs {
+		if candidate.matches(mediaType) == 2 {
+			return candidate, true
+		}
+	}
+	return nil, false
+}
+
+// MediaTypes lists the media types of every format, for error messages
+func MediaTypes() []string {
+	types := make([]string, 0, len(codecs))
+	for _, c := range codecs {
+		types = append(types, c.mediaType)
+	}
+	return types
+}
@@ -0,0 +1,173 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sample struct {
+	ID        string            `json:"id"`
+	Count     int               `json:"count"`
+	Score     float64           `json:"score"`
+	Draft     bool              `json:"draft"`
+	Tags      []string          `json:"tags"`
+	Created   time.Time         `json:"created_at"`
+	Published *time.Time        `json:"published_at,omitempty"`
+	Owner     *owner            `json:"owner,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Secret    string            `json:"-"`
+}
+
+type owner struct {
+	Name string `json:"name"`
+}
+
+type problem struct {
+	Title string `json:"title"`
+}
+
+func (problem) XMLRoot() xml.Name {
+	return xml.Name{Space: "urn:ietf:rfc:7807", Local: "problem"}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected *Codec
+	}{
+		{"", JSON},
+		{"*/*", JSON},
+		{"application/json", JSON},
+		{"application/xml", XML},
+		{"text/xml", XML},
+		{"application/msgpack", MessagePack},
+		{"application/x-msgpack", MessagePack},
+		{"application/cbor", CBOR},
+		{"application/problem+json", JSON},
+		{"application/xml, application/json", XML},
+		{"application/xml;q=0.5, application/cbor", CBOR},
+		{"application/json;q=0, */*", XML},
+		{`application/json; profile="urn:article-api:paginated"`, JSON},
+		{"text/html, application/*;q=0.8", JSON},
+		{"text/html, application/msgpack;q=0.1", MessagePack},
+	}
+
+	for _, tt := range tests {
+		got, ok := Negotiate(tt.accept)
+		if !ok || got != tt.expected {
+			t.Errorf("Negotiate(%q) = %v, %v, expected %s", tt.accept, got, ok, tt.expected.Name())
+		}
+	}
+
+	for _, accept := range []string{"text/html", "application/json;q=0", "image/png, text/*"} {
+		if got, ok := Negotiate(accept); ok {
+			t.Errorf("Negotiate(%q) = %s, expected no acceptable format", accept, got.Name())
+		}
+	}
+}
+
+func TestForContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    *Codec
+	}{
+		{"", JSON},
+		{"application/json; charset=utf-8", JSON},
+		{"application/merge-patch+json", JSON},
+		{"application/xml", XML},
+		{"text/xml; charset=utf-8", XML},
+		{"application/vnd.msgpack", MessagePack},
+		{"application/cbor", CBOR},
+	}
+
+	for _, tt := range tests {
+		got, ok := ForContentType(tt.contentType)
+		if !ok || got != tt.expected {
+			t.Errorf("ForContentType(%q) = %v, %v, expected %s", tt.contentType, got, ok, tt.expected.Name())
+		}
+	}
+
+	for _, contentType := range []string{"text/plain", "application/*", "*/*", "multipart/form-data; boundary=x", ";"} {
+		if got, ok := ForContentType(contentType); ok {
+			t.Errorf("ForContentType(%q) = %s, expected an unsupported type", contentType, got.Name())
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	published := time.Date(2025, 10, 1, 12, 30, 0, 500, time.UTC)
+	value := sample{
+		ID:        "article-1",
+		Count:     42,
+		Score:     0.75,
+		Draft:     true,
+		Tags:      []string{"go", "web"},
+		Created:   time.Date(2025, 9, 30, 8, 0, 0, 0, time.UTC),
+		Published: &published,
+		Owner:     &owner{Name: "Jane"},
+		Labels:    map[string]string{"lang": "en", "1st": "yes"},
+		Secret:    "hidden",
+	}
+
+	for _, c := range codecs {
+		var buf bytes.Buffer
+		if err := c.Encode(&buf, value); err != nil {
+			t.Fatalf("%s: failed to encode: %v", c.Name(), err)
+		}
+		var decoded sample
+		if err := c.Decode(&buf, &decoded); err != nil {
+			t.Fatalf("%s: failed to decode: %v", c.Name(), err)
+		}
+
+		expected := value
+		expected.Secret = ""
+		if !decoded.Created.Equal(expected.Created) || !decoded.Published.Equal(*expected.Published) {
+			t.Errorf("%s: expected times %v and %v, got %v and %v", c.Name(), expected.Created, expected.Published, decoded.Created, decoded.Published)
+		}
+		decoded.Created, decoded.Published = expected.Created, expected.Published
+		if !reflect.DeepEqual(decoded, expected) {
+			t.Errorf("%s: round trip = %+v, expected %+v", c.Name(), decoded, expected)
+		}
+	}
+}
+
+func TestXMLEncoding(t *testing.T) {
+	var buf bytes.Buffer
+	if err := XML.Encode(&buf, []sample{{ID: "a&b", Tags: []string{}, Labels: map[string]string{"1st": "yes"}}}); err != nil {
+		t.Fatal(err)
+	}
+	expected := xml.Header + `<response><item><id>a&amp;b</id><count>0</count><score>0</score><draft>false</draft>` +
+		`<tags></tags><created_at>0001-01-01T00:00:00Z</created_at><labels><entry key="1st">yes</entry></labels></item></response>` + "\n"
+	if buf.String() != expected {
+		t.Errorf("XML = %s, expected %s", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := XML.Encode(&buf, problem{Title: "Not Found"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `<problem xmlns="urn:ietf:rfc:7807"><title>Not Found</title></problem>`) {
+		t.Errorf("Expected an RFC 7807 problem element, got %s", buf.String())
+	}
+}
+
+func TestXMLDecoding(t *testing.T) {
+	var decoded sample
+	body := `<article><id> padded </id><count>7</count><tags/><unknown>ignored</unknown></article>`
+	if err := XML.Decode(strings.NewReader(body), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != " padded " || decoded.Count != 7 || decoded.Tags == nil || len(decoded.Tags) != 0 {
+		t.Errorf("Unexpected decoded value %+v", decoded)
+	}
+
+	for _, body := range []string{`<article><count>seven</count></article>`, `<article><id>unclosed</article>`, ``} {
+		if err := XML.Decode(strings.NewReader(body), &decoded); err == nil {
+			t.Errorf("Expected an error decoding %q", body)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package codec
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// XMLRooter names the root element of a value encoded as XML; other values
+// are encoded in a response element
+type XMLRooter interface {
+	XMLRoot() xml.Name
+}
+
+// itemElement holds each value of an array
+const itemElement = "item"
+
+// entryElement holds an object member whose key is not a valid element name,
+// with the key in its key attribute
+const entryElement = "entry"
+
+// encodeXML writes the JSON encoding of v as XML, so XML responses carry the
+// same names and values as JSON ones. Null members are left out.
+func encodeXML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	root := xml.StartElement{Name: xml.Name{Local: "response"}}
+	if rooter, ok := v.(XMLRooter); ok {
+		name := rooter.XMLRoot()
+		root.Name.Local = name.Local
+		if name.Space != "" {
+			root.Attr = []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: name.Space}}
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	encoder := xml.NewEncoder(w)
+	if err := writeXMLValue(decoder, encoder, root, true); err != nil {
+		return err
+	}
+	if err := encoder.Flush(); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// writeXMLValue writes the next JSON value of decoder as the element start.
+// Nulls are written as empty elements only when required is set.
+func writeXMLValue(decoder *json.Decoder, encoder *xml.Encoder, start xml.StartElement, required bool) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch value := token.(type) {
+	case nil:
+		if !required {
+			return nil
+		}
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+	case json.Delim:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		for decoder.More() {
+			child := xml.StartElement{Name: xml.Name{Local: itemElement}}
+			if value == '{' {
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				child = memberElement(key.(string))
+			}
+			if err := writeXMLValue(decoder, encoder, child, value == '['); err != nil {
+				return err
+			}
+		}
+		// Consume the closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	default:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		if err := encoder.EncodeToken(xml.CharData(fmt.Sprint(value))); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// memberElement returns the element of an object member
+func memberElement(key string) xml.StartElement {
+	if isElementName(key) {
+		return xml.StartElement{Name: xml.Name{Local: key}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: entryElement},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+	}
+}
+
+// isElementName reports whether a key can be used as an element name as is
+func isElementName(key string) bool {
+	if key == "" || strings.HasPrefix(strings.ToLower(key), "xml") {
+		return false
+	}
+	for i, r := range key {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// xmlNode is a parsed element
+type xmlNode struct {
+	name     string
+	key      string
+	text     string
+	children []*xmlNode
+}
+
+// decodeXML reads an XML document into v. The root element may have any name;
+// its children are matched to the json names of v's fields and converted to
+// the JSON those fields decode from, the reverse of encodeXML.
+func decodeXML(r io.Reader, v interface{}) error {
+	root, err := parseXML(xml.NewDecoder(r))
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(xmlToJSON(root, reflect.TypeOf(v)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// parseXML reads the root element of a document
+func parseXML(decoder *xml.Decoder) (*xmlNode, error) {
+	var stack []*xmlNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, errors.New("xml: no root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local}
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "key" {
+					node.key = attr.Value
+				}
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			}
+			stack = append(stack, node)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		case xml.EndElement:
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return node, nil
+			}
+		}
+	}
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// xmlToJSON converts an element to the JSON value a field of type t decodes
+// from. Elements that do not match a field are dropped, as JSON ignores
+// unknown members.
+func xmlToJSON(node *xmlNode, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return node.text
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := jsonFields(t)
+		object := map[string]interface{}{}
+		for _, child := range node.children {
+			name := child.memberName()
+			if field, ok := fields[name]; ok {
+				object[name] = xmlToJSON(child, field)
+			}
+		}
+		return object
+	case reflect.Map:
+		object := map[string]interface{}{}
+		for _, child := range node.children {
+			object[child.memberName()] = xmlToJSON(child, t.Elem())
+		}
+		return object
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return strings.TrimSpace(node.text)
+		}
+		items := []interface{}{}
+		for _, child := range node.children {
+			items = append(items, xmlToJSON(child, t.Elem()))
+		}
+		return items
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		// Invalid literals fail when the JSON is marshalled
+		return json.RawMessage(strings.TrimSpace(node.text))
+	case reflect.Interface:
+		if len(node.children) > 0 {
+			return xmlToJSON(node, reflect.TypeOf(map[string]interface{}(nil)))
+		}
+	}
+	return node.text
+}
+
+// memberName returns the object key an element was written for
+func (n *xmlNode) memberName() string {
+	if n.name == entryElement && n.key != "" {
+		return n.key
+	}
+	return n.name
+}
+
+// jsonFields maps the json names of a struct's fields, including those of
+// embedded structs, to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range jsonFields(embedded) {
+					if _, exists := fields[embeddedName]; !exists {
+						fields[embeddedName] = embeddedType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
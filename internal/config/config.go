@@ -1,8 +1,11 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -16,9 +19,21 @@ type Config struct {
 
 // AppConfig holds application-level configuration
 type AppConfig struct {
-	Name     string
-	Env      string
-	Location string
+	Name                string
+	Env                 string
+	Location            string
+	APIKey              string
+	BaseURL             string
+	CursorSecret        string
+	BatchMaxSize        int
+	FeedSize            int
+	RelatedTagWeight    float64
+	RelatedAuthorWeight float64
+	RelatedTitleWeight  float64
+	DuplicateThreshold  float64
+	TrashRetention      time.Duration
+	TrashPurgeInterval  time.Duration
+	PublishInterval     time.Duration
 }
 
 // ServerConfig holds HTTP server configuration
@@ -48,6 +63,7 @@ type RedisConfig struct {
 	DB            int
 	InitTimeout   int
 	ArticleTTL    int
+	ListTTL       int
 	ArticlePrefix string
 }
 
@@ -55,9 +71,21 @@ type RedisConfig struct {
 func LoadConfig() *Config {
 	return &Config{
 		App: AppConfig{
-			Name:     getEnv("APP_NAME", "article_api"),
-			Env:      getEnv("APP_ENV", "dev"),
-			Location: getEnv("SERVER_LOCATION", "Asia/Jakarta"),
+			Name:                getEnv("APP_NAME", "article_api"),
+			Env:                 getEnv("APP_ENV", "dev"),
+			Location:            getEnv("SERVER_LOCATION", "Asia/Jakarta"),
+			APIKey:              getEnv("API_KEY", ""),
+			BaseURL:             getEnv("BASE_URL", ""),
+			CursorSecret:        getEnv("CURSOR_SECRET", randomCursorSecret()),
+			BatchMaxSize:        getIntEnv("BATCH_MAX_SIZE", 100),
+			FeedSize:            getIntEnv("FEED_SIZE", 20),
+			RelatedTagWeight:    getFloatEnv("RELATED_TAG_WEIGHT", 1.0),
+			RelatedAuthorWeight: getFloatEnv("RELATED_AUTHOR_WEIGHT", 0.5),
+			RelatedTitleWeight:  getFloatEnv("RELATED_TITLE_WEIGHT", 1.0),
+			DuplicateThreshold:  getFloatEnv("DUPLICATE_SIMILARITY_THRESHOLD", 0.8),
+			TrashRetention:      getDurationEnv("TRASH_RETENTION", 30*24*time.Hour),
+			TrashPurgeInterval:  getPositiveDurationEnv("TRASH_PURGE_INTERVAL", time.Hour),
+			PublishInterval:     getPositiveDurationEnv("PUBLISH_SCHEDULER_INTERVAL", time.Minute),
 		},
 		Server: ServerConfig{
 			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
@@ -81,11 +109,23 @@ func LoadConfig() *Config {
 			DB:            getIntEnv("REDIS_DB", 3),
 			InitTimeout:   getIntEnv("REDIS_INIT_TIMEOUT", 5),
 			ArticleTTL:    getIntEnv("REDIS_ARTICLE_TTL", 86400),
+			ListTTL:       getIntEnv("REDIS_LIST_TTL", 300),
 			ArticlePrefix: getEnv("REDIS_ARTICLE_PREFIX", "article-"),
 		},
 	}
 }
 
+// randomCursorSecret signs cursors when CURSOR_SECRET is unset. It is generated
+// once per process, so such cursors stop working after a restart and are not
+// shared between instances.
+var randomCursorSecret = sync.OnceValue(func() string {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic("failed to generate a cursor secret: " + err.Error())
+	}
+	return hex.EncodeToString(secret)
+})
+
 // getEnv gets an environment variable with a fallback default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -104,6 +144,16 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getFloatEnv gets a floating-point environment variable with a fallback default value
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // getDurationEnv gets a duration environment variable with a fallback default value
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
@@ -113,3 +163,12 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getPositiveDurationEnv gets a duration environment variable that must be
+// positive, such as a ticker interval, falling back to the default otherwise
+func getPositiveDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if duration := getDurationEnv(key, defaultValue); duration > 0 {
+		return duration
+	}
+	return defaultValue
+}
@@ -55,7 +55,7 @@ func TestLoadConfigDefaults(t *testing.T) {
 		"SERVER_HOST", "SERVER_PORT", "SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT", "SERVER_IDLE_TIMEOUT",
 		"DB_HOST", "DB_PORT", "DB_NAME", "DB_USER", "DB_PASSWORD",
 		"REDIS_HOST", "REDIS_PORT", "REDIS_PASSWORD", "REDIS_DB",
-		"API_KEY",
+		"API_KEY", "CURSOR_SECRET",
 	}
 
 	for _, envVar := range envVars {
@@ -77,4 +77,31 @@ func TestLoadConfigDefaults(t *testing.T) {
 	if cfg.Redis.Host != "localhost" {
 		t.Errorf("Expected default Redis host 'localhost', got '%s'", cfg.Redis.Host)
 	}
+	if cfg.App.RelatedTagWeight != 1 || cfg.App.RelatedAuthorWeight != 0.5 || cfg.App.RelatedTitleWeight != 1 {
+		t.Errorf("Unexpected default related weights %v, %v, %v", cfg.App.RelatedTagWeight, cfg.App.RelatedAuthorWeight, cfg.App.RelatedTitleWeight)
+	}
+	if cfg.App.DuplicateThreshold != 0.8 {
+		t.Errorf("Expected default duplicate threshold 0.8, got %v", cfg.App.DuplicateThreshold)
+	}
+	// Without CURSOR_SECRET cursors are signed with a random per-process secret
+	if len(cfg.App.CursorSecret) != 64 || cfg.App.CursorSecret != LoadConfig().App.CursorSecret {
+		t.Errorf("Expected a stable random cursor secret, got %q", cfg.App.CursorSecret)
+	}
+}
+
+func TestLoadConfigIntervals(t *testing.T) {
+	// Non-positive intervals would make the background tickers panic
+	for _, value := range []string{"0", "-5m"} {
+		os.Setenv("TRASH_PURGE_INTERVAL", value)
+		os.Setenv("PUBLISH_SCHEDULER_INTERVAL", value)
+		cfg := LoadConfig()
+		if cfg.App.TrashPurgeInterval != time.Hour {
+			t.Errorf("TRASH_PURGE_INTERVAL=%s: expected the default 1h, got %v", value, cfg.App.TrashPurgeInterval)
+		}
+		if cfg.App.PublishInterval != time.Minute {
+			t.Errorf("PUBLISH_SCHEDULER_INTERVAL=%s: expected the default 1m, got %v", value, cfg.App.PublishInterval)
+		}
+	}
+	os.Unsetenv("TRASH_PURGE_INTERVAL")
+	os.Unsetenv("PUBLISH_SCHEDULER_INTERVAL")
 }
@@ -0,0 +1,56 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalid is returned for cursors that are malformed or carry a bad signature
+var ErrInvalid = errors.New("invalid cursor")
+
+// Cursor is a keyset position in the article listing: the sort key values of a
+// boundary row, ending with its id, and the direction to page in from there
+type Cursor struct {
+	// Sort is the sort expression the values were taken from
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+	// Backward pages towards the start of the listing, before the boundary row
+	Backward bool `json:"b,omitempty"`
+}
+
+// Encode serializes the cursor into an opaque, URL-safe token signed with secret
+func Encode(c Cursor, secret string) string {
+	payload, _ := json.Marshal(c)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + sign(encoded, secret)
+}
+
+// Decode verifies a token produced by Encode and returns its cursor
+func Decode(token, secret string) (Cursor, error) {
+	var c Cursor
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(sign(encoded, secret))) {
+		return c, ErrInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return c, ErrInvalid
+	}
+	if err := json.Unmarshal(payload, &c); err != nil || len(c.Values) == 0 {
+		return c, ErrInvalid
+	}
+	return c, nil
+}
+
+// sign returns the URL-safe HMAC-SHA256 signature of an encoded payload
+func sign(encoded, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
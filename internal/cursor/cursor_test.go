@@ -0,0 +1,44 @@
+package cursor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	c := Cursor{
+		Sort:     "author,-created_at",
+		Values:   []string{"John Doe", "2025-09-04T10:30:00.123456Z", "article-1"},
+		Backward: true,
+	}
+
+	decoded, err := Decode(Encode(c, "secret"), "secret")
+	if err != nil {
+		t.Fatalf("Failed to decode cursor: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, c) {
+		t.Errorf("Expected %+v, got %+v", c, decoded)
+	}
+}
+
+func TestDecodeRejectsTampering(t *testing.T) {
+	token := Encode(Cursor{Sort: "-created_at", Values: []string{"2025-09-04T10:30:00Z", "article-1"}}, "secret")
+
+	if _, err := Decode(token, "other-secret"); err != ErrInvalid {
+		t.Errorf("Expected ErrInvalid for a foreign signature, got %v", err)
+	}
+
+	forged := Encode(Cursor{Sort: "-created_at", Values: []string{"2025-09-04T10:30:00Z", "article-2"}}, "other-secret")
+	payload, _, _ := strings.Cut(forged, ".")
+	_, signature, _ := strings.Cut(token, ".")
+	if _, err := Decode(payload+"."+signature, "secret"); err != ErrInvalid {
+		t.Errorf("Expected ErrInvalid for a swapped payload, got %v", err)
+	}
+
+	for _, token := range []string{"", "garbage", "a.b"} {
+		if _, err := Decode(token, "secret"); err != ErrInvalid {
+			t.Errorf("Expected ErrInvalid for %q, got %v", token, err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Operation kinds produced by a diff
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// Op is a run of text that is equal in both inputs, or only present in one of them
+type Op struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Lines diffs a and b line by line
+func Lines(a, b string) []Op {
+	return compute(splitLines(a), splitLines(b))
+}
+
+// Words diffs a and b word by word, keeping whitespace attached to the preceding word
+func Words(a, b string) []Op {
+	return compute(splitWords(a), splitWords(b))
+}
+
+// splitLines splits text into lines, keeping the line terminators
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitWords splits text into words, each followed by its trailing whitespace
+func splitWords(text string) []string {
+	var tokens []string
+	start := 0
+	inSpace := false
+	for i, r := range text {
+		space := unicode.IsSpace(r)
+		if inSpace && !space {
+			tokens = append(tokens, text[start:i])
+			start = i
+		}
+		inSpace = space
+	}
+	if start < len(text) {
+		tokens = append(tokens, text[start:])
+	}
+	return tokens
+}
+
+// Limits that keep a diff of two large, heavily rewritten texts cheap. Beyond
+// them the changed middle of the inputs is reported as a single replacement.
+const (
+	// MaxTokens bounds the number of differing lines or words searched
+	MaxTokens = 20000
+	// MaxEdits bounds the number of insertions and deletions searched for
+	MaxEdits = 1000
+)
+
+// compute diffs two token slices and merges consecutive tokens with the same
+// operation. The common prefix and suffix are matched directly; the rest runs
+// through Myers' O(ND) algorithm within MaxTokens and MaxEdits.
+func compute(a, b []string) []Op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []Op
+	for _, token := range a[:prefix] {
+		ops = append(ops, Op{Op: OpEqual, Text: token})
+	}
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	middle, ok := myers(middleA, middleB)
+	if !ok {
+		middle = replace(middleA, middleB)
+	}
+	ops = append(ops, middle...)
+	for _, token := range a[len(a)-suffix:] {
+		ops = append(ops, Op{Op: OpEqual, Text: token})
+	}
+
+	merged := []Op{}
+	for _, op := range ops {
+		if last := len(merged) - 1; last >= 0 && merged[last].Op == op.Op {
+			merged[last].Text += op.Text
+			continue
+		}
+		merged = append(merged, op)
+	}
+	return merged
+}
+
+// replace is the edit script deleting all of a and inserting all of b
+func replace(a, b []string) []Op {
+	var ops []Op
+	for _, token := range a {
+		ops = append(ops, Op{Op: OpDelete, Text: token})
+	}
+	for _, token := range b {
+		ops = append(ops, Op{Op: OpInsert, Text: token})
+	}
+	return ops
+}
+
+// myers returns the shortest edit script from a to b, one op per token, or
+// false when the inputs exceed MaxTokens or need more than MaxEdits edits.
+// Step d only snapshots the 2d+1 diagonals it can reach, so the trace takes
+// O(D²) memory.
+func myers(a, b []string) ([]Op, bool) {
+	n, m := len(a), len(b)
+	max := n + m
+	if max == 0 {
+		return nil, true
+	}
+	if max > MaxTokens {
+		return nil, false
+	}
+	if max > MaxEdits {
+		max = MaxEdits
+	}
+
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+
+	found := false
+search:
+	for d := 0; d <= max; d++ {
+		// trace[d] holds diagonals -d..d as they were before step d
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break search
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	// Walk the trace backwards to recover the edit script
+	var reversed []Op
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0 && (x > 0 || y > 0); d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[d+k-1] < v[d+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		var prevX int
+		if d > 0 {
+			prevX = v[d+prevK]
+		}
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, Op{Op: OpEqual, Text: a[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			reversed = append(reversed, Op{Op: OpInsert, Text: b[y]})
+		} else {
+			x--
+			reversed = append(reversed, Op{Op: OpDelete, Text: a[x]})
+		}
+	}
+
+	ops := make([]Op, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		ops = append(ops, reversed[i])
+	}
+	return ops, true
+}
@@ -0,0 +1,109 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// apply rebuilds both inputs from a diff so the output can be checked for consistency
+func apply(ops []Op) (string, string) {
+	var a, b strings.Builder
+	for _, op := range ops {
+		if op.Op != OpInsert {
+			a.WriteString(op.Text)
+		}
+		if op.Op != OpDelete {
+			b.WriteString(op.Text)
+		}
+	}
+	return a.String(), b.String()
+}
+
+func TestLines(t *testing.T) {
+	a := "first line\nsecond line\nthird line\n"
+	b := "first line\nchanged line\nthird line\nfourth line\n"
+
+	ops := Lines(a, b)
+
+	expected := []Op{
+		{Op: OpEqual, Text: "first line\n"},
+		{Op: OpDelete, Text: "second line\n"},
+		{Op: OpInsert, Text: "changed line\n"},
+		{Op: OpEqual, Text: "third line\n"},
+		{Op: OpInsert, Text: "fourth line\n"},
+	}
+	if !reflect.DeepEqual(ops, expected) {
+		t.Errorf("Unexpected line diff: %+v", ops)
+	}
+}
+
+func TestWords(t *testing.T) {
+	a := "The quick brown fox jumps"
+	b := "The quick red fox leaps"
+
+	ops := Words(a, b)
+
+	gotA, gotB := apply(ops)
+	if gotA != a || gotB != b {
+		t.Errorf("Diff does not reproduce inputs: %q / %q", gotA, gotB)
+	}
+
+	deleted := []string{}
+	for _, op := range ops {
+		if op.Op == OpDelete {
+			deleted = append(deleted, op.Text)
+		}
+	}
+	if strings.Join(deleted, "|") != "brown |jumps" {
+		t.Errorf("Expected 'brown ' and 'jumps' to be deleted, got %v", deleted)
+	}
+}
+
+func TestIdenticalAndEmpty(t *testing.T) {
+	if ops := Lines("same\n", "same\n"); len(ops) != 1 || ops[0].Op != OpEqual {
+		t.Errorf("Expected a single equal op, got %+v", ops)
+	}
+	if ops := Words("", ""); len(ops) != 0 {
+		t.Errorf("Expected no ops for empty inputs, got %+v", ops)
+	}
+	if ops := Words("", "new text"); len(ops) != 1 || ops[0].Op != OpInsert {
+		t.Errorf("Expected a single insert op, got %+v", ops)
+	}
+}
+
+func TestReproducesInputs(t *testing.T) {
+	pairs := [][2]string{
+		{"a b c d e f", "x a c d y f z"},
+		{"one two three", "three two one"},
+		{"shared prefix then old tail", "shared prefix then new tail"},
+		{"only old", ""},
+	}
+	for _, pair := range pairs {
+		gotA, gotB := apply(Words(pair[0], pair[1]))
+		if gotA != pair[0] || gotB != pair[1] {
+			t.Errorf("Words(%q, %q) reproduces %q / %q", pair[0], pair[1], gotA, gotB)
+		}
+	}
+}
+
+func TestLargeRewriteFallsBackToReplace(t *testing.T) {
+	var a, b strings.Builder
+	a.WriteString("Intro ")
+	b.WriteString("Intro ")
+	for i := 0; i < MaxEdits; i++ {
+		a.WriteString("old ")
+		b.WriteString("new ")
+	}
+	a.WriteString("outro")
+	b.WriteString("outro")
+
+	ops := Words(a.String(), b.String())
+	if len(ops) != 4 || ops[0].Op != OpEqual || ops[1].Op != OpDelete || ops[2].Op != OpInsert || ops[3].Op != OpEqual {
+		t.Fatalf("Expected the common ends around a single replacement, got %d ops", len(ops))
+	}
+	gotA, gotB := apply(ops)
+	if gotA != a.String() || gotB != b.String() {
+		t.Error("Replacement does not reproduce inputs")
+	}
+}
@@ -0,0 +1,50 @@
+package feed
+
+import (
+	"time"
+)
+
+// Feed is a syndication feed independent of its output format
+type Feed struct {
+	// ID is a permanent IRI identifying the feed
+	ID          string
+	Title       string
+	Description string
+	// Link is the page the feed mirrors and Self the URL of the feed itself
+	Link string
+	Self string
+	// NextURL continues the feed with older entries; only JSON Feed uses it
+	NextURL string
+	Updated time.Time
+	Entries []Entry
+}
+
+// Entry is a single article in a feed
+type Entry struct {
+	// ID is a permanent IRI identifying the entry across feeds and formats
+	ID      string
+	Title   string
+	Link    string
+	Summary string
+	// Content is the full plain text and ContentHTML its HTML rendering;
+	// only JSON Feed carries full content
+	Content     string
+	ContentHTML string
+	Author      string
+	AuthorURL   string
+	Published   time.Time
+	Updated     time.Time
+	Categories  []string
+}
+
+// LastUpdated returns the latest entry update, or the Unix epoch for an empty
+// feed so its output stays stable between renderings
+func LastUpdated(entries []Entry) time.Time {
+	latest := time.Unix(0, 0).UTC()
+	for _, entry := range entries {
+		if entry.Updated.After(latest) {
+			latest = entry.Updated
+		}
+	}
+	return latest
+}
@@ -0,0 +1,124 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFeed() *Feed {
+	published := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("WIB", 7*3600))
+	entries := []Entry{
+		{
+			ID:         "urn:article-api:article:article-1",
+			Title:      "Go & <XML>",
+			Link:       "http://example.com/articles/by-slug/go-xml",
+			Summary:    "An excerpt",
+			Author:     "John Doe",
+			Published:  published,
+			Updated:    published.Add(time.Hour),
+			Categories: []string{"go", "xml"},
+		},
+	}
+	return &Feed{
+		ID:      "urn:article-api:feed:articles",
+		Title:   "Articles",
+		Link:    "http://example.com/articles",
+		Self:    "http://example.com/feeds/articles.atom",
+		Updated: LastUpdated(entries),
+		Entries: entries,
+	}
+}
+
+func TestAtom(t *testing.T) {
+	body, err := testFeed().Atom()
+	if err != nil {
+		t.Fatalf("Failed to render Atom: %v", err)
+	}
+
+	var doc atomFeed
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("Atom is not well-formed: %v\n%s", err, body)
+	}
+	if doc.XMLName.Space != "http://www.w3.org/2005/Atom" || doc.Updated != "2025-01-01T21:04:05Z" {
+		t.Errorf("Unexpected feed %+v", doc)
+	}
+	if len(doc.Entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(doc.Entries))
+	}
+	entry := doc.Entries[0]
+	if entry.Title != "Go & <XML>" || entry.Published != "2025-01-01T20:04:05Z" || entry.Author.Name != "John Doe" || len(entry.Categories) != 2 {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+}
+
+func TestRSS(t *testing.T) {
+	body, err := testFeed().RSS()
+	if err != nil {
+		t.Fatalf("Failed to render RSS: %v", err)
+	}
+
+	var doc struct {
+		Channel struct {
+			Items []struct {
+				GUID    string `xml:"guid"`
+				PubDate string `xml:"pubDate"`
+				Creator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("RSS is not well-formed: %v\n%s", err, body)
+	}
+	if len(doc.Channel.Items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(doc.Channel.Items))
+	}
+	item := doc.Channel.Items[0]
+	if item.GUID != "urn:article-api:article:article-1" || item.PubDate != "Wed, 01 Jan 2025 20:04:05 +0000" || item.Creator != "John Doe" {
+		t.Errorf("Unexpected item %+v", item)
+	}
+	if !strings.Contains(string(body), `<atom:link rel="self"`) {
+		t.Errorf("Expected a self link, got\n%s", body)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	f := testFeed()
+	f.NextURL = "http://example.com/feeds/articles.json?cursor=abc"
+	f.Entries[0].Content = "Full body"
+	f.Entries[0].ContentHTML = "<p>Full body</p>"
+	f.Entries[0].AuthorURL = "http://example.com/authors/author-1/feed.atom"
+
+	body, err := f.JSON()
+	if err != nil {
+		t.Fatalf("Failed to render JSON Feed: %v", err)
+	}
+
+	var doc jsonFeed
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("Invalid JSON Feed: %v\n%s", err, body)
+	}
+	if doc.Version != "https://jsonfeed.org/version/1.1" || doc.NextURL != f.NextURL || doc.FeedURL != f.Self {
+		t.Errorf("Unexpected feed %+v", doc)
+	}
+	if len(doc.Items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(doc.Items))
+	}
+	item := doc.Items[0]
+	if item.ContentText != "Full body" || item.ContentHTML != "<p>Full body</p>" || item.DatePublished != "2025-01-01T20:04:05Z" {
+		t.Errorf("Unexpected item %+v", item)
+	}
+	if len(item.Authors) != 1 || item.Authors[0].Name != "John Doe" || item.Authors[0].URL != f.Entries[0].AuthorURL {
+		t.Errorf("Unexpected authors %+v", item.Authors)
+	}
+
+	// Items always carry content, falling back to the summary
+	f.Entries[0].Content, f.Entries[0].ContentHTML = "", ""
+	body, _ = f.JSON()
+	json.Unmarshal(body, &doc)
+	if doc.Items[0].ContentText != "An excerpt" {
+		t.Errorf("Expected the summary as content_text, got %+v", doc.Items[0])
+	}
+}
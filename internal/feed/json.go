@@ -0,0 +1,86 @@
+package feed
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// jsonFeedVersion identifies the JSON Feed version documents conform to
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+// jsonFeed is a JSON Feed 1.1 document
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	NextURL     string         `json:"next_url,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	ContentHTML   string           `json:"content_html,omitempty"`
+	ContentText   string           `json:"content_text,omitempty"`
+	Summary       string           `json:"summary,omitempty"`
+	DatePublished string           `json:"date_published,omitempty"`
+	DateModified  string           `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// jsonFeedTime formats a timestamp as RFC 3339, leaving zero times out
+func jsonFeedTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// JSON renders the feed as a JSON Feed 1.1 document. Entries without any
+// content fall back to their summary as content_text, which JSON Feed requires.
+func (f *Feed) JSON() ([]byte, error) {
+	doc := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.Self,
+		Description: f.Description,
+		NextURL:     f.NextURL,
+		Items:       []jsonFeedItem{},
+	}
+	for _, entry := range f.Entries {
+		item := jsonFeedItem{
+			ID:            entry.ID,
+			URL:           entry.Link,
+			Title:         entry.Title,
+			ContentHTML:   entry.ContentHTML,
+			ContentText:   entry.Content,
+			Summary:       entry.Summary,
+			DatePublished: jsonFeedTime(entry.Published),
+			DateModified:  jsonFeedTime(entry.Updated),
+			Tags:          entry.Categories,
+		}
+		if item.ContentHTML == "" && item.ContentText == "" {
+			item.ContentText = entry.Summary
+		}
+		if entry.Author != "" {
+			item.Authors = []jsonFeedAuthor{{Name: entry.Author, URL: entry.AuthorURL}}
+		}
+		doc.Items = append(doc.Items, item)
+	}
+
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(body, '\n'), nil
+}
@@ -2,26 +2,84 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
+	"article-api/internal/config"
+	"article-api/internal/cursor"
 	"article-api/internal/models"
 	"article-api/internal/repository"
 )
 
 // ArticleHandler handles HTTP requests for articles
 type ArticleHandler struct {
-	repo repository.ArticleRepositoryInterface
+	repo         repository.ArticleRepositoryInterface
+	adminAPIKey  string
+	cursorSecret string
+	batchMaxSize int
 }
 
 // NewArticleHandler creates a new article handler
 func NewArticleHandler(repo repository.ArticleRepositoryInterface) *ArticleHandler {
-	return &ArticleHandler{repo: repo}
+	cfg := config.LoadConfig()
+
+	return &ArticleHandler{
+		repo:         repo,
+		adminAPIKey:  cfg.App.APIKey,
+		cursorSecret: cfg.App.CursorSecret,
+		batchMaxSize: cfg.App.BatchMaxSize,
+	}
 }
 
-// ListArticles handles GET /articles
-func (h *ArticleHandler) ListArticles(w http.ResponseWriter, r *http.Request) {
+// canView reports whether the request may see an article in its current
+// lifecycle status. Requests carry no authenticated author identity, so
+// unpublished articles are an admin-only view.
+func (h *ArticleHandler) canView(r *http.Request, article *models.Article) bool {
+	return article.Status == models.StatusPublished || h.isAdmin(r)
+}
+
+// viewableArticle loads an article the request may see. Unpublished articles
+// are only visible to admins and are reported as not found to anyone else. On
+// failure it writes the error response and returns false.
+func (h *ArticleHandler) viewableArticle(w http.ResponseWriter, r *http.Request, id string) (*models.Article, bool) {
+	article, err := h.repo.GetArticleByID(id)
+	if err != nil {
+		h.writeArticleError(w, r, id, err)
+		return nil, false
+	}
+	if !h.canView(r, article) {
+		writeProblem(w, r, http.StatusNotFound, fmt.Sprintf("Article %s not found", id))
+		return nil, false
+	}
+	return article, true
+}
+
+// renderBody sets the HTML rendering of an article's body for a single-article
+// response. A body that fails to render is logged and left without body_html.
+func (h *ArticleHandler) renderBody(article *models.Article) {
+	rendered, err := h.repo.RenderArticleBody(article)
+	if err != nil {
+		log.Printf("Failed to render article %s: %v", article.ID, err)
+		return
+	}
+	article.BodyHTML = rendered
+}
+
+// isAdmin reports whether the request carries the configured admin API key.
+// Admin views are disabled entirely when no API_KEY is configured.
+func (h *ArticleHandler) isAdmin(r *http.Request) bool {
+	return h.adminAPIKey != "" && r.Header.Get("X-API-Key") == h.adminAPIKey
+}
+
+// parseListParams reads the listing query parameters shared by the list and
+// export endpoints. On invalid input it writes the error response and returns false.
+func (h *ArticleHandler) parseListParams(w http.ResponseWriter, r *http.Request) (repository.ListArticlesParams, bool) {
 	// Parse query parameters
 	search := r.URL.Query().Get("search")
 	authorName := r.URL.Query().Get("author")
@@ -36,20 +94,176 @@ func (h *ArticleHandler) ListArticles(w http.ResponseWriter, r *http.Request) {
 		Limit:      limit,
 	}
 
+	// Filters are validated together so every bad parameter is reported at once
+	query := r.URL.Query()
+	var invalid []InvalidParam
+
+	sort, err := repository.ParseSort(query.Get("sort"))
+	if err != nil {
+		invalid = append(invalid, InvalidParam{Name: "sort", Reason: err.Error()})
+	} else if repository.SortsByRelevance(sort) && search == "" {
+		invalid = append(invalid, InvalidParam{Name: "sort", Reason: "relevance requires a search term"})
+	}
+	params.Sort = sort
+
+	if status := query.Get("status"); status != "" {
+		if !models.IsValidStatus(status) {
+			invalid = append(invalid, InvalidParam{Name: "status", Reason: "must be one of draft, scheduled, published, archived"})
+		}
+		params.Status = status
+	}
+
+	for _, bound := range []struct {
+		name string
+		dest **time.Time
+	}{
+		{"created_after", &params.CreatedAfter},
+		{"created_before", &params.CreatedBefore},
+	} {
+		value := query.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			invalid = append(invalid, InvalidParam{Name: bound.name, Reason: "must be an RFC 3339 timestamp such as 2025-09-04T10:30:00Z"})
+			continue
+		}
+		*bound.dest = &parsed
+	}
+	if params.CreatedAfter != nil && params.CreatedBefore != nil && !params.CreatedAfter.Before(*params.CreatedBefore) {
+		invalid = append(invalid, InvalidParam{Name: "created_before", Reason: "must be later than created_after"})
+	}
+
+	// Sparse fieldsets limit both the columns fetched and the response
+	if expr := query.Get("fields"); expr != "" {
+		fields, err := repository.ParseListFields(expr)
+		if err != nil {
+			invalid = append(invalid, InvalidParam{Name: "fields", Reason: err.Error()})
+		} else if search := repository.SearchListFields(fields); len(search) > 0 && params.Search == "" {
+			invalid = append(invalid, InvalidParam{Name: "fields", Reason: strings.Join(search, ", ") + " require a search term"})
+		}
+		params.Fields = fields
+	}
+
+	// Author filters accept comma-separated or repeated exact IDs
+	params.AuthorIDs = parseListParam(query, "author_id")
+	params.ExcludeAuthorIDs = parseListParam(query, "exclude_author_id")
+
+	if len(invalid) > 0 {
+		writeInvalidParams(w, r, invalid)
+		return params, false
+	}
+
+	// Cursors take precedence over page and only continue the sort they were issued for
+	if token := query.Get("cursor"); token != "" {
+		if repository.SortsByRelevance(sort) {
+			writeProblem(w, r, http.StatusBadRequest, "cursor cannot be combined with sort=relevance")
+			return params, false
+		}
+		position, err := cursor.Decode(token, h.cursorSecret)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "cursor is invalid or has been tampered with")
+			return params, false
+		}
+		if position.Sort != repository.FormatSort(sort) {
+			writeProblem(w, r, http.StatusBadRequest, fmt.Sprintf("cursor was issued for sort=%s", position.Sort))
+			return params, false
+		}
+		params.Cursor = &position
+	}
+
+	// Tag filters accept comma-separated or repeated values
+	params.Tag = models.NormalizeTag(query.Get("tag"))
+	params.TagsAny = normalizeTagParams(parseListParam(query, "tags_any"))
+	params.TagsAll = normalizeTagParams(parseListParam(query, "tags_all"))
+
+	// Only published articles are public; admins see every status
+	params.AllStatuses = h.isAdmin(r)
+
+	// Trashed articles are an admin-only view
+	if includeDeleted := r.URL.Query().Get("include_deleted"); includeDeleted != "" {
+		if !h.isAdmin(r) {
+			writeProblem(w, r, http.StatusForbidden, "include_deleted requires a valid X-API-Key")
+			return params, false
+		}
+		switch includeDeleted {
+		case "true":
+			params.IncludeDeleted = true
+		case "only":
+			params.OnlyDeleted = true
+		case "false":
+		default:
+			writeProblem(w, r, http.StatusBadRequest, "include_deleted must be one of true, false, only")
+			return params, false
+		}
+	}
+
+	return params, true
+}
+
+// ListArticles handles GET /articles
+func (h *ArticleHandler) ListArticles(w http.ResponseWriter, r *http.Request) {
+	params, ok := h.parseListParams(w, r)
+	if !ok {
+		return
+	}
+
 	result, err := h.repo.ListArticles(params)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to list articles: %v", err), http.StatusInternalServerError)
 		return
 	}
 
+	meta := listMeta{
+		Total:      result.Total,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: totalPages(result.Total, result.Limit),
+	}
+	if result.NextCursor != nil {
+		meta.NextCursor = cursor.Encode(*result.NextCursor, h.cursorSecret)
+	}
+	if result.PrevCursor != nil {
+		meta.PrevCursor = cursor.Encode(*result.PrevCursor, h.cursorSecret)
+	}
+	links := paginationLinks(r, meta)
+
 	// Set pagination headers
 	w.Header().Set("X-Total-Count", fmt.Sprintf("%d", result.Total))
 	w.Header().Set("X-Page", fmt.Sprintf("%d", result.Page))
 	w.Header().Set("X-Limit", fmt.Sprintf("%d", result.Limit))
-	w.Header().Set("X-Total-Pages", fmt.Sprintf("%d", (result.Total+result.Limit-1)/result.Limit))
+	w.Header().Set("X-Total-Pages", fmt.Sprintf("%d", meta.TotalPages))
+	if meta.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", meta.NextCursor)
+	}
+	if meta.PrevCursor != "" {
+		w.Header().Set("X-Prev-Cursor", meta.PrevCursor)
+	}
+	setLinkHeader(w, links)
+
+	var body interface{} = result.Articles
+	if len(params.Fields) > 0 {
+		if body, err = projectListItems(result.Articles, params.Fields); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			return
+		}
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(result.Articles); err != nil {
+	// Clients that cannot read headers opt into an envelope carrying the same data
+	if wantsEnvelope(r) {
+		if articles, ok := body.([]models.ArticleListItem); ok && articles == nil {
+			body = []models.ArticleListItem{}
+		}
+		c := responseCodec(r)
+		contentType := c.MediaType() + `; profile="` + paginatedProfile + `"`
+		if err := writeCodec(w, c, contentType, http.StatusOK, listEnvelope{Data: body, Meta: meta, Links: links}); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := writeEncoded(w, r, http.StatusOK, body); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		return
 	}
@@ -66,17 +280,43 @@ func parseIntParam(value string, defaultValue int) int {
 	return defaultValue
 }
 
+// parseListParam collects a multi-value query parameter given either as
+// repeated keys or as comma-separated values
+func parseListParam(query url.Values, key string) []string {
+	var values []string
+	for _, raw := range query[key] {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// normalizeTagParams normalizes tag filter values, dropping duplicates
+func normalizeTagParams(values []string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		if tag := models.NormalizeTag(value); tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // CreateArticle handles POST /articles
 func (h *ArticleHandler) CreateArticle(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateArticleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	if err := decodeRequest(r, &req); err != nil {
+		http.Error(w, invalidPayload(r), http.StatusBadRequest)
 		return
 	}
 
-	// Basic validation
-	if req.AuthorID == "" || req.Title == "" || req.Body == "" {
-		http.Error(w, "Missing required fields: author_id, title, body", http.StatusBadRequest)
+	if err := req.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -87,16 +327,371 @@ func (h *ArticleHandler) CreateArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	article, err := h.repo.CreateArticle(req)
+	// Editors may knowingly create a duplicate with force=true
+	force := r.URL.Query().Get("force") == "true"
+	if force && !h.isAdmin(r) {
+		writeProblem(w, r, http.StatusForbidden, "Creating a duplicate with force=true requires a valid X-API-Key")
+		return
+	}
+
+	create := h.repo.CreateUniqueArticle
+	if force {
+		create = h.repo.CreateArticle
+	}
+	article, err := create(req)
+	var duplicate *repository.DuplicateArticleError
+	if errors.As(err, &duplicate) {
+		writeDuplicateProblem(w, r, duplicate.Duplicate)
+		return
+	}
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to create article: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(article); err != nil {
+	h.renderBody(article)
+
+	w.Header().Set("ETag", articleETag(r, article.Version))
+	if err := writeEncoded(w, r, http.StatusCreated, article); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeDuplicateProblem writes a 409 problem pointing at the existing article
+// a new one duplicates
+func writeDuplicateProblem(w http.ResponseWriter, r *http.Request, duplicate *models.DuplicateArticle) {
+	w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"duplicate\"", "/articles/"+url.PathEscape(duplicate.ID)))
+	writeProblemDetails(w, r, Problem{
+		Type:        "about:blank",
+		Title:       http.StatusText(http.StatusConflict),
+		Status:      http.StatusConflict,
+		Detail:      describeDuplicate(duplicate),
+		Instance:    r.URL.Path,
+		DuplicateOf: duplicate,
+	})
+}
+
+// describeDuplicate explains which existing article a new one duplicates and
+// sets the duplicate's link
+func describeDuplicate(duplicate *models.DuplicateArticle) string {
+	duplicate.Href = "/articles/" + url.PathEscape(duplicate.ID)
+
+	detail := fmt.Sprintf("The author already has an article with this %s: %s", duplicate.Match, duplicate.ID)
+	if duplicate.Match == models.DuplicateMatchSimilar {
+		detail = fmt.Sprintf("The author already has a very similar article: %s", duplicate.ID)
+	}
+	return detail + "; editors can create it anyway with force=true"
+}
+
+// GetArticle handles GET /articles/{id}
+func (h *ArticleHandler) GetArticle(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) == 0 {
+		writeProblem(w, r, http.StatusNotFound, "Article ID is required")
+		return
+	}
+
+	article, ok := h.viewableArticle(w, r, segments[0])
+	if !ok {
+		return
+	}
+
+	h.renderBody(article)
+
+	w.Header().Set("ETag", articleETag(r, article.Version))
+	if err := writeEncoded(w, r, http.StatusOK, article); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		return
 	}
 }
+
+// GetArticleBySlug handles GET /articles/by-slug/{slug}. Previous slugs
+// redirect permanently to the article's canonical slug.
+func (h *ArticleHandler) GetArticleBySlug(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/by-slug/")
+	if len(segments) != 1 {
+		writeProblem(w, r, http.StatusNotFound, "Article slug is required")
+		return
+	}
+
+	article, err := h.repo.GetArticleBySlug(segments[0])
+	if err != nil {
+		h.writeArticleError(w, r, segments[0], err)
+		return
+	}
+
+	if !h.canView(r, article) {
+		writeProblem(w, r, http.StatusNotFound, fmt.Sprintf("Article %s not found", segments[0]))
+		return
+	}
+
+	if article.Slug != segments[0] {
+		location := "/articles/by-slug/" + article.Slug
+		if r.URL.RawQuery != "" {
+			location += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+		return
+	}
+
+	h.renderBody(article)
+
+	w.Header().Set("ETag", articleETag(r, article.Version))
+	if err := writeEncoded(w, r, http.StatusOK, article); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdateArticle handles PUT /articles/{id}
+func (h *ArticleHandler) UpdateArticle(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) == 0 {
+		writeProblem(w, r, http.StatusNotFound, "Article ID is required")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	var req models.UpdateArticleRequest
+	if err := decodeRequest(r, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, invalidPayload(r))
+		return
+	}
+
+	// Only articles the request may see can be replaced
+	if _, ok := h.viewableArticle(w, r, segments[0]); !ok {
+		return
+	}
+
+	h.applyUpdate(w, r, segments[0], req, expectedVersion)
+}
+
+// PatchArticle handles PATCH /articles/{id} using JSON Merge Patch (RFC 7396)
+func (h *ArticleHandler) PatchArticle(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) == 0 {
+		writeProblem(w, r, http.StatusNotFound, "Article ID is required")
+		return
+	}
+
+	contentType := strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0])
+	if contentType != "application/merge-patch+json" && contentType != "application/json" {
+		writeProblem(w, r, http.StatusUnsupportedMediaType, "PATCH requires application/merge-patch+json")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid JSON payload")
+		return
+	}
+
+	current, ok := h.viewableArticle(w, r, segments[0])
+	if !ok {
+		return
+	}
+
+	if expectedVersion == 0 {
+		// Guard the read-modify-write cycle against concurrent writers
+		expectedVersion = current.Version
+	} else if expectedVersion != current.Version {
+		writeProblem(w, r, http.StatusPreconditionFailed, fmt.Sprintf("Article has been modified, current version is %d", current.Version))
+		return
+	}
+
+	req := models.UpdateArticleRequest{
+		AuthorID: current.AuthorID,
+		Title:    current.Title,
+		Body:     current.Body,
+	}
+	fields := map[string]*string{
+		"author_id":      &req.AuthorID,
+		"title":          &req.Title,
+		"body":           &req.Body,
+		"status":         &req.Status,
+		"content_format": &req.ContentFormat,
+	}
+	clearPublishedAt := false
+	for name, raw := range patch {
+		if name == "tags" {
+			// null clears the tags, like an empty list
+			req.Tags = []string{}
+			if string(raw) != "null" {
+				if err := json.Unmarshal(raw, &req.Tags); err != nil {
+					writeProblem(w, r, http.StatusBadRequest, "Field tags must be a list of strings")
+					return
+				}
+			}
+			continue
+		}
+		if name == "published_at" {
+			if string(raw) == "null" {
+				clearPublishedAt = true
+				continue
+			}
+			var publishedAt time.Time
+			if err := json.Unmarshal(raw, &publishedAt); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, "Field published_at must be an RFC 3339 timestamp")
+				return
+			}
+			req.PublishedAt = &publishedAt
+			continue
+		}
+
+		target, ok := fields[name]
+		if !ok {
+			writeProblem(w, r, http.StatusBadRequest, fmt.Sprintf("Field %s cannot be patched", name))
+			return
+		}
+		if string(raw) == "null" {
+			writeProblem(w, r, http.StatusBadRequest, fmt.Sprintf("Field %s is required and cannot be removed", name))
+			return
+		}
+		if err := json.Unmarshal(raw, target); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, fmt.Sprintf("Field %s must be a string", name))
+			return
+		}
+	}
+
+	// The repository keeps the lifecycle unless a status is sent, so a lone
+	// published_at change is applied against the current status
+	if _, ok := patch["published_at"]; ok && req.Status == "" {
+		req.Status = current.Status
+	}
+
+	// Only drafts go without a publication time, and becoming a draft clears it
+	if clearPublishedAt && req.Status != models.StatusDraft {
+		writeProblem(w, r, http.StatusUnprocessableEntity, fmt.Sprintf("Field published_at can only be removed from draft articles, not %s ones", req.Status))
+		return
+	}
+
+	h.applyUpdate(w, r, segments[0], req, expectedVersion)
+}
+
+// DeleteArticle handles DELETE /articles/{id} by moving the article to the trash
+func (h *ArticleHandler) DeleteArticle(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) == 0 {
+		writeProblem(w, r, http.StatusNotFound, "Article ID is required")
+		return
+	}
+
+	if !h.isAdmin(r) {
+		writeProblem(w, r, http.StatusForbidden, "Deleting articles requires a valid X-API-Key")
+		return
+	}
+
+	if err := h.repo.DeleteArticle(segments[0]); err != nil {
+		h.writeArticleError(w, r, segments[0], err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreArticle handles POST /articles/{id}/restore
+func (h *ArticleHandler) RestoreArticle(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) == 0 {
+		writeProblem(w, r, http.StatusNotFound, "Article ID is required")
+		return
+	}
+
+	if !h.isAdmin(r) {
+		writeProblem(w, r, http.StatusForbidden, "Restoring articles requires a valid X-API-Key")
+		return
+	}
+
+	article, err := h.repo.RestoreArticle(segments[0])
+	if err != nil {
+		var notFound *repository.ArticleNotFoundError
+		if errors.As(err, &notFound) {
+			writeProblem(w, r, http.StatusNotFound, fmt.Sprintf("Article %s is not in the trash", segments[0]))
+			return
+		}
+		h.writeArticleError(w, r, segments[0], err)
+		return
+	}
+
+	h.renderBody(article)
+
+	w.Header().Set("ETag", articleETag(r, article.Version))
+	if err := writeEncoded(w, r, http.StatusOK, article); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// applyUpdate validates and stores a full article replacement
+func (h *ArticleHandler) applyUpdate(w http.ResponseWriter, r *http.Request, id string, req models.UpdateArticleRequest, expectedVersion int) {
+	if req.AuthorID == "" || req.Title == "" || req.Body == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Missing required fields: author_id, title, body")
+		return
+	}
+
+	if err := models.ValidatePublication(req.Status, req.PublishedAt); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := models.ValidateContentFormat(req.ContentFormat); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Tags != nil {
+		tags, err := models.NormalizeTags(req.Tags)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		req.Tags = tags
+	}
+
+	if _, err := h.repo.GetAuthorByID(req.AuthorID); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "Author not found")
+		return
+	}
+
+	req.EditedBy = r.Header.Get("X-Editor")
+
+	article, err := h.repo.UpdateArticle(id, req, expectedVersion)
+	if err != nil {
+		h.writeArticleError(w, r, id, err)
+		return
+	}
+
+	h.renderBody(article)
+
+	w.Header().Set("ETag", articleETag(r, article.Version))
+	if err := writeEncoded(w, r, http.StatusOK, article); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeArticleError maps repository errors for a single article to problem responses
+func (h *ArticleHandler) writeArticleError(w http.ResponseWriter, r *http.Request, id string, err error) {
+	var notFound *repository.ArticleNotFoundError
+	var conflict *repository.VersionConflictError
+	switch {
+	case errors.As(err, &notFound):
+		writeProblem(w, r, http.StatusNotFound, fmt.Sprintf("Article %s not found", id))
+	case errors.As(err, &conflict):
+		writeProblem(w, r, http.StatusPreconditionFailed, fmt.Sprintf("Article has been modified, current version is %d", conflict.CurrentVersion))
+	default:
+		writeProblem(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to process article: %v", err))
+	}
+}
@@ -2,24 +2,48 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"article-api/internal/codec"
+	"article-api/internal/cursor"
 	"article-api/internal/models"
+	"article-api/internal/render"
 	"article-api/internal/repository"
+	"article-api/internal/slug"
 )
 
 // MockArticleRepository is a mock implementation of ArticleRepository for testing
 type MockArticleRepository struct {
-	articles []models.ArticleListItem
-	authors  map[string]*models.Author
+	articles   []models.ArticleListItem
+	details    map[string]*models.Article
+	revisions  map[string][]models.ArticleRevision
+	oldSlugs   map[string]string
+	authors    map[string]*models.Author
+	lastParams repository.ListArticlesParams
+	// failTitle makes batch creation fail for articles with this title
+	failTitle     string
+	authorLookups int
+	// related holds the related articles of each article
+	related      map[string][]models.RelatedArticle
+	relatedLimit int
+	// exportErr makes exports fail after the first article
+	exportErr error
 }
 
 func NewMockArticleRepository() *MockArticleRepository {
 	return &MockArticleRepository{
+		details:   map[string]*models.Article{},
+		revisions: map[string][]models.ArticleRevision{},
+		oldSlugs:  map[string]string{},
 		authors: map[string]*models.Author{
 			"author-1": {ID: "author-1", Name: "John Doe"},
 			"author-2": {ID: "author-2", Name: "Jane Smith"},
@@ -29,12 +53,51 @@ func NewMockArticleRepository() *MockArticleRepository {
 
 func (m *MockArticleRepository) ListArticles(params repository.ListArticlesParams) (*repository.ListArticlesResult, error) {
 	// Simple mock implementation - in real tests you'd filter based on params
-	return &repository.ListArticlesResult{
+	m.lastParams = params
+	result := &repository.ListArticlesResult{
 		Articles: m.articles,
 		Total:    len(m.articles),
 		Page:     params.Page,
 		Limit:    params.Limit,
-	}, nil
+	}
+	if len(m.articles) > 0 {
+		last := m.articles[len(m.articles)-1]
+		result.NextCursor = &cursor.Cursor{
+			Sort:   repository.FormatSort(params.Sort),
+			Values: []string{last.CreatedAt.Format(time.RFC3339Nano), last.ID},
+		}
+	}
+	return result, nil
+}
+
+func (m *MockArticleRepository) ExportArticles(ctx context.Context, params repository.ListArticlesParams, fn func(*models.Article) error) error {
+	m.lastParams = params
+	for _, item := range m.articles {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		article := &models.Article{
+			ID:          item.ID,
+			AuthorID:    item.AuthorID,
+			Title:       item.Title,
+			Slug:        item.Slug,
+			Body:        item.Body,
+			CreatedAt:   item.CreatedAt,
+			UpdatedAt:   item.CreatedAt,
+			Version:     1,
+			Status:      item.Status,
+			PublishedAt: item.PublishedAt,
+			Tags:        item.Tags,
+			Author:      item.Author,
+		}
+		if err := fn(article); err != nil {
+			return err
+		}
+		if m.exportErr != nil {
+			return m.exportErr
+		}
+	}
+	return nil
 }
 
 func (m *MockArticleRepository) CreateArticle(req models.CreateArticleRequest) (*models.Article, error) {
@@ -44,12 +107,24 @@ func (m *MockArticleRepository) CreateArticle(req models.CreateArticleRequest) (
 	}
 
 	article := &models.Article{
-		ID:        "test-article-1",
-		AuthorID:  req.AuthorID,
-		Title:     req.Title,
-		Body:      req.Body,
-		CreatedAt: time.Now(),
-		Author:    author,
+		ID:            "test-article-1",
+		AuthorID:      req.AuthorID,
+		Title:         req.Title,
+		Slug:          slug.Make(req.Title),
+		Body:          req.Body,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		Version:       1,
+		Status:        models.StatusPublished,
+		Tags:          req.Tags,
+		Author:        author,
+		ContentFormat: models.ContentFormatPlain,
+	}
+	if req.Status != "" {
+		article.Status = req.Status
+	}
+	if req.ContentFormat != "" {
+		article.ContentFormat = req.ContentFormat
 	}
 
 	// Convert Article to ArticleListItem for the mock
@@ -61,9 +136,162 @@ func (m *MockArticleRepository) CreateArticle(req models.CreateArticleRequest) (
 		Author:    article.Author,
 	}
 	m.articles = append(m.articles, articleListItem)
+	m.details[article.ID] = article
+	m.recordRevision(article, req.AuthorID)
+	return article, nil
+}
+
+func (m *MockArticleRepository) FindDuplicateArticle(req models.CreateArticleRequest) (*models.DuplicateArticle, error) {
+	normalize := func(text string) string {
+		return strings.ToLower(strings.Join(strings.Fields(text), " "))
+	}
+	for _, article := range m.details {
+		if article.AuthorID != req.AuthorID || article.DeletedAt != nil {
+			continue
+		}
+		match := ""
+		switch {
+		case normalize(article.Title) == normalize(req.Title):
+			match = models.DuplicateMatchTitle
+		case normalize(article.Body) == normalize(req.Body):
+			match = models.DuplicateMatchBody
+		default:
+			continue
+		}
+		return &models.DuplicateArticle{ID: article.ID, Title: article.Title, Slug: article.Slug, Match: match, Similarity: 1}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockArticleRepository) CreateUniqueArticle(req models.CreateArticleRequest) (*models.Article, error) {
+	duplicate, err := m.FindDuplicateArticle(req)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		return nil, &repository.DuplicateArticleError{Duplicate: duplicate}
+	}
+	return m.CreateArticle(req)
+}
+
+func (m *MockArticleRepository) recordRevision(article *models.Article, editedBy string) {
+	m.revisions[article.ID] = append(m.revisions[article.ID], models.ArticleRevision{
+		ArticleID: article.ID,
+		Revision:  article.Version,
+		AuthorID:  article.AuthorID,
+		Title:     article.Title,
+		Body:      article.Body,
+		EditedBy:  editedBy,
+		CreatedAt: article.UpdatedAt,
+	})
+}
+
+func (m *MockArticleRepository) GetArticleByID(id string) (*models.Article, error) {
+	article, exists := m.details[id]
+	if !exists || article.DeletedAt != nil {
+		return nil, &repository.ArticleNotFoundError{}
+	}
+	return article, nil
+}
+
+func (m *MockArticleRepository) GetArticleBySlug(articleSlug string) (*models.Article, error) {
+	for _, article := range m.details {
+		if article.Slug == articleSlug {
+			return m.GetArticleByID(article.ID)
+		}
+	}
+	if id, exists := m.oldSlugs[articleSlug]; exists {
+		return m.GetArticleByID(id)
+	}
+	return nil, &repository.ArticleNotFoundError{}
+}
+
+func (m *MockArticleRepository) RenderArticleBody(article *models.Article) (string, error) {
+	return render.HTML(article.Body, article.ContentFormat)
+}
+
+func (m *MockArticleRepository) RelatedArticles(id string, limit int) ([]models.RelatedArticle, error) {
+	m.relatedLimit = limit
+	related := append([]models.RelatedArticle{}, m.related[id]...)
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return related, nil
+}
+
+func (m *MockArticleRepository) DeleteArticle(id string) error {
+	article, exists := m.details[id]
+	if !exists || article.DeletedAt != nil {
+		return &repository.ArticleNotFoundError{}
+	}
+	now := time.Now()
+	article.DeletedAt = &now
+	return nil
+}
+
+func (m *MockArticleRepository) RestoreArticle(id string) (*models.Article, error) {
+	article, exists := m.details[id]
+	if !exists || article.DeletedAt == nil {
+		return nil, &repository.ArticleNotFoundError{}
+	}
+	article.DeletedAt = nil
 	return article, nil
 }
 
+func (m *MockArticleRepository) UpdateArticle(id string, req models.UpdateArticleRequest, expectedVersion int) (*models.Article, error) {
+	article, exists := m.details[id]
+	if !exists {
+		return nil, &repository.ArticleNotFoundError{}
+	}
+	if expectedVersion > 0 && expectedVersion != article.Version {
+		return nil, &repository.VersionConflictError{CurrentVersion: article.Version}
+	}
+
+	updated := *article
+	updated.AuthorID = req.AuthorID
+	updated.Title = req.Title
+	if newSlug := slug.Make(req.Title); newSlug != article.Slug {
+		m.oldSlugs[article.Slug] = id
+		updated.Slug = newSlug
+	}
+	updated.Body = req.Body
+	updated.UpdatedAt = time.Now()
+	updated.Version++
+	if req.Tags != nil {
+		updated.Tags = req.Tags
+	}
+	if req.Status != "" {
+		updated.Status = req.Status
+		updated.PublishedAt = req.PublishedAt
+	}
+	if req.ContentFormat != "" {
+		updated.ContentFormat = req.ContentFormat
+	}
+	updated.Author = m.authors[req.AuthorID]
+	m.details[id] = &updated
+	m.recordRevision(&updated, req.EditedBy)
+	return &updated, nil
+}
+
+func (m *MockArticleRepository) ListRevisions(articleID string) ([]models.ArticleRevision, error) {
+	if _, err := m.GetArticleByID(articleID); err != nil {
+		return nil, err
+	}
+	return m.revisions[articleID], nil
+}
+
+func (m *MockArticleRepository) GetRevision(articleID string, revision int) (*models.ArticleRevision, error) {
+	if _, err := m.GetArticleByID(articleID); err != nil {
+		return nil, err
+	}
+	for _, rev := range m.revisions[articleID] {
+		if rev.Revision == revision {
+			return &rev, nil
+		}
+	}
+	return nil, &repository.RevisionNotFoundError{Revision: revision}
+}
+
 func (m *MockArticleRepository) GetAuthorByID(id string) (*models.Author, error) {
 	author, exists := m.authors[id]
 	if !exists {
@@ -72,6 +300,47 @@ func (m *MockArticleRepository) GetAuthorByID(id string) (*models.Author, error)
 	return author, nil
 }
 
+func (m *MockArticleRepository) GetAuthorsByIDs(ids []string) (map[string]*models.Author, error) {
+	m.authorLookups++
+	authors := map[string]*models.Author{}
+	for _, id := range ids {
+		if author, exists := m.authors[id]; exists {
+			authors[id] = author
+		}
+	}
+	return authors, nil
+}
+
+func (m *MockArticleRepository) CreateArticles(reqs []models.CreateArticleRequest, atomic, unique bool) ([]*models.Article, []error, error) {
+	articles := make([]*models.Article, len(reqs))
+	errs := make([]error, len(reqs))
+	for i, req := range reqs {
+		var failure error
+		if req.Title == m.failTitle {
+			failure = fmt.Errorf("insert failed")
+		} else if duplicate, _ := m.FindDuplicateArticle(req); unique && duplicate != nil {
+			failure = &repository.DuplicateArticleError{Duplicate: duplicate}
+		}
+		if failure != nil {
+			if atomic {
+				for j := range reqs {
+					articles[j], errs[j] = nil, &repository.BatchAbortedError{Index: i}
+				}
+				errs[i] = failure
+				return articles, errs, nil
+			}
+			errs[i] = failure
+			continue
+		}
+		article, err := m.CreateArticle(req)
+		if err == nil {
+			article.ID = fmt.Sprintf("test-article-%d", i+1)
+		}
+		articles[i], errs[i] = article, err
+	}
+	return articles, errs, nil
+}
+
 func TestArticleHandler_ListArticles(t *testing.T) {
 	mockRepo := NewMockArticleRepository()
 	handler := NewArticleHandler(mockRepo)
@@ -206,3 +475,1213 @@ func TestArticleHandler_CreateArticle_InvalidAuthor(t *testing.T) {
 		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
 	}
 }
+
+func TestArticleHandler_GetArticle(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Single Article",
+		Body:     "Full article body",
+	})
+
+	req := httptest.NewRequest("GET", "/articles/"+created.ID, nil)
+	w := httptest.NewRecorder()
+
+	handler.GetArticle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var article models.Article
+	if err := json.NewDecoder(w.Body).Decode(&article); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if article.Body != "Full article body" {
+		t.Errorf("Expected body 'Full article body', got '%s'", article.Body)
+	}
+}
+
+func TestArticleHandler_GetArticle_NotFound(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	req := httptest.NewRequest("GET", "/articles/missing", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetArticle(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("Expected problem content type, got '%s'", contentType)
+	}
+}
+
+func TestArticleHandler_UpdateArticle(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Original Title",
+		Body:     "Original body",
+	})
+
+	reqBody := models.UpdateArticleRequest{
+		AuthorID: "author-2",
+		Title:    "Updated Title",
+		Body:     "Updated body",
+	}
+	jsonBody, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("PUT", "/articles/"+created.ID, bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+
+	handler.UpdateArticle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if etag := w.Header().Get("ETag"); etag != `"2"` {
+		t.Errorf("Expected ETag '\"2\"', got '%s'", etag)
+	}
+
+	var article models.Article
+	if err := json.NewDecoder(w.Body).Decode(&article); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if article.Title != reqBody.Title || article.AuthorID != reqBody.AuthorID {
+		t.Errorf("Expected article to be replaced, got %+v", article)
+	}
+}
+
+func TestArticleHandler_UpdateArticle_StaleIfMatch(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Original Title",
+		Body:     "Original body",
+	})
+	mockRepo.UpdateArticle(created.ID, models.UpdateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Concurrent edit",
+		Body:     "Original body",
+	}, 0)
+
+	jsonBody, _ := json.Marshal(models.UpdateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Stale edit",
+		Body:     "Original body",
+	})
+	req := httptest.NewRequest("PUT", "/articles/"+created.ID, bytes.NewBuffer(jsonBody))
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+
+	handler.UpdateArticle(w, req)
+
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status code %d, got %d", http.StatusPreconditionFailed, w.Code)
+	}
+
+	// If-Match uses strong comparison, so a weak tag never matches
+	req = httptest.NewRequest("PUT", "/articles/"+created.ID, bytes.NewBuffer(jsonBody))
+	req.Header.Set("If-Match", `W/"2"`)
+	w = httptest.NewRecorder()
+	handler.UpdateArticle(w, req)
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status code %d for a weak tag, got %d", http.StatusPreconditionFailed, w.Code)
+	}
+}
+
+func TestArticleHandler_PatchArticle(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Original Title",
+		Body:     "Original body",
+	})
+
+	req := httptest.NewRequest("PATCH", "/articles/"+created.ID, bytes.NewBufferString(`{"title":"Patched Title"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w := httptest.NewRecorder()
+
+	handler.PatchArticle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var article models.Article
+	if err := json.NewDecoder(w.Body).Decode(&article); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if article.Title != "Patched Title" {
+		t.Errorf("Expected title 'Patched Title', got '%s'", article.Title)
+	}
+	if article.Body != "Original body" {
+		t.Errorf("Expected body to be preserved, got '%s'", article.Body)
+	}
+
+	// Removing a required field is rejected
+	req = httptest.NewRequest("PATCH", "/articles/"+created.ID, bytes.NewBufferString(`{"body":null}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w = httptest.NewRecorder()
+
+	handler.PatchArticle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Only drafts may drop their publication time
+	for _, tt := range []struct {
+		body     string
+		expected int
+	}{
+		{`{"published_at":null}`, http.StatusUnprocessableEntity},
+		{`{"status":"draft","published_at":null}`, http.StatusOK},
+	} {
+		req = httptest.NewRequest("PATCH", "/articles/"+created.ID, bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		w = httptest.NewRecorder()
+		handler.PatchArticle(w, req)
+		if w.Code != tt.expected {
+			t.Errorf("%s: expected status code %d, got %d", tt.body, tt.expected, w.Code)
+		}
+	}
+}
+
+func TestArticleHandler_DeleteAndRestoreArticle(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+	handler.adminAPIKey = "admin-key"
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Doomed Article",
+		Body:     "Soon in the trash",
+	})
+
+	// Deleting and restoring both require the admin API key
+	req := httptest.NewRequest("DELETE", "/articles/"+created.ID, nil)
+	w := httptest.NewRecorder()
+	handler.DeleteArticle(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status code %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	req = httptest.NewRequest("DELETE", "/articles/"+created.ID, nil)
+	req.Header.Set("X-API-Key", "admin-key")
+	w = httptest.NewRecorder()
+	handler.DeleteArticle(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status code %d, got %d", http.StatusNoContent, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/"+created.ID, nil)
+	w = httptest.NewRecorder()
+	handler.GetArticle(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected trashed article to return %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/articles/"+created.ID+"/restore", nil)
+	w = httptest.NewRecorder()
+	handler.RestoreArticle(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/articles/"+created.ID+"/restore", nil)
+	req.Header.Set("X-API-Key", "admin-key")
+	w = httptest.NewRecorder()
+	handler.RestoreArticle(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestArticleHandler_ListArticles_IncludeDeleted(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+	handler.adminAPIKey = "admin-key"
+
+	req := httptest.NewRequest("GET", "/articles?include_deleted=true", nil)
+	w := httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/articles?include_deleted=only", nil)
+	req.Header.Set("X-API-Key", "admin-key")
+	w = httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if !mockRepo.lastParams.OnlyDeleted {
+		t.Error("Expected trash listing to request only deleted articles")
+	}
+}
+
+func TestArticleHandler_Revisions(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Draft title",
+		Body:     "line one\nline two\n",
+	})
+
+	jsonBody, _ := json.Marshal(models.UpdateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Final title",
+		Body:     "line one\nline 2\n",
+	})
+	req := httptest.NewRequest("PUT", "/articles/"+created.ID, bytes.NewBuffer(jsonBody))
+	req.Header.Set("X-Editor", "editor-1")
+	w := httptest.NewRecorder()
+	handler.UpdateArticle(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/"+created.ID+"/revisions", nil)
+	w = httptest.NewRecorder()
+	handler.ListRevisions(w, req)
+
+	var revisions []models.ArticleRevision
+	if err := json.NewDecoder(w.Body).Decode(&revisions); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("Expected 2 revisions, got %d", len(revisions))
+	}
+	if revisions[1].EditedBy != "editor-1" {
+		t.Errorf("Expected revision to be edited by 'editor-1', got '%s'", revisions[1].EditedBy)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/"+created.ID+"/revisions/diff?from=1&to=2", nil)
+	w = httptest.NewRecorder()
+	handler.DiffRevisions(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var result models.RevisionDiff
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(result.Body) != 3 || result.Body[1].Text != "line two\n" {
+		t.Errorf("Unexpected body diff: %+v", result.Body)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/"+created.ID+"/revisions/9", nil)
+	w = httptest.NewRecorder()
+	handler.GetRevision(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestArticleHandler_Revisions_DraftHidden(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+	handler.adminAPIKey = "admin-key"
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Secret plans",
+		Body:     "Not ready yet",
+		Status:   models.StatusDraft,
+	})
+
+	endpoints := []struct {
+		path    string
+		handler http.HandlerFunc
+	}{
+		{"/articles/" + created.ID + "/revisions", handler.ListRevisions},
+		{"/articles/" + created.ID + "/revisions/1", handler.GetRevision},
+		{"/articles/" + created.ID + "/revisions/diff?from=1&to=1", handler.DiffRevisions},
+	}
+	for _, endpoint := range endpoints {
+		req := httptest.NewRequest("GET", endpoint.path, nil)
+		w := httptest.NewRecorder()
+		endpoint.handler(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("GET %s: expected status code %d for a draft, got %d", endpoint.path, http.StatusNotFound, w.Code)
+		}
+
+		req = httptest.NewRequest("GET", endpoint.path, nil)
+		req.Header.Set("X-API-Key", "admin-key")
+		w = httptest.NewRecorder()
+		endpoint.handler(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s: expected status code %d for an admin, got %d", endpoint.path, http.StatusOK, w.Code)
+		}
+	}
+}
+
+func TestArticleHandler_DraftVisibility(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+	handler.adminAPIKey = "admin-key"
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Work in progress",
+		Body:     "Not ready yet",
+		Status:   models.StatusDraft,
+	})
+
+	req := httptest.NewRequest("GET", "/articles/"+created.ID, nil)
+	w := httptest.NewRecorder()
+	handler.GetArticle(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected draft to be hidden with status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// An unauthenticated author header does not reveal drafts
+	req = httptest.NewRequest("GET", "/articles/"+created.ID, nil)
+	req.Header.Set("X-Author-ID", "author-1")
+	w = httptest.NewRecorder()
+	handler.GetArticle(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected a forged X-Author-ID to get %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/by-slug/"+created.Slug, nil)
+	req.Header.Set("X-Author-ID", "author-1")
+	w = httptest.NewRecorder()
+	handler.GetArticleBySlug(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected a forged X-Author-ID to get %d by slug, got %d", http.StatusNotFound, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/articles?status=draft", nil)
+	req.Header.Set("X-Author-ID", "author-1")
+	w = httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if mockRepo.lastParams.AllStatuses || mockRepo.lastParams.Status != models.StatusDraft {
+		t.Errorf("Unexpected list params: %+v", mockRepo.lastParams)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/"+created.ID, nil)
+	req.Header.Set("X-API-Key", "admin-key")
+	w = httptest.NewRecorder()
+	handler.GetArticle(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected draft to be visible to admins, got %d", w.Code)
+	}
+}
+
+func TestArticleHandler_DraftUpdates(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+	handler.adminAPIKey = "admin-key"
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Work in progress",
+		Body:     "Not ready yet",
+		Status:   models.StatusDraft,
+	})
+
+	// Unpublished articles can neither be read back nor changed through updates
+	requests := []struct {
+		method  string
+		body    string
+		handler http.HandlerFunc
+	}{
+		{"PATCH", `{}`, handler.PatchArticle},
+		{"PATCH", `{"status": "published"}`, handler.PatchArticle},
+		{"PUT", `{"author_id": "author-1", "title": "Leaked", "body": "Changed", "status": "published"}`, handler.UpdateArticle},
+	}
+	for _, tt := range requests {
+		req := httptest.NewRequest(tt.method, "/articles/"+created.ID, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		tt.handler(w, req)
+		if w.Code != http.StatusNotFound || strings.Contains(w.Body.String(), "Not ready yet") {
+			t.Errorf("%s %s: expected status code %d, got %d: %s", tt.method, tt.body, http.StatusNotFound, w.Code, w.Body.String())
+		}
+	}
+	if article, _ := mockRepo.GetArticleByID(created.ID); article.Status != models.StatusDraft || article.Title != "Work in progress" {
+		t.Errorf("Expected the draft to be unchanged, got %+v", article)
+	}
+
+	req := httptest.NewRequest("PATCH", "/articles/"+created.ID, strings.NewReader(`{"status": "published"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", "admin-key")
+	w := httptest.NewRecorder()
+	handler.PatchArticle(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected admins to publish the draft, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestArticleHandler_CreateArticle_ScheduledNeedsFutureDate(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	past := time.Now().Add(-time.Hour)
+	jsonBody, _ := json.Marshal(models.CreateArticleRequest{
+		AuthorID:    "author-1",
+		Title:       "Scheduled Article",
+		Body:        "Coming soon",
+		Status:      models.StatusScheduled,
+		PublishedAt: &past,
+	})
+	req := httptest.NewRequest("POST", "/articles", bytes.NewBuffer(jsonBody))
+	w := httptest.NewRecorder()
+
+	handler.CreateArticle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestArticleHandler_GetArticleBySlug(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Crème Brûlée Basics",
+		Body:     "Caramelise the sugar",
+	})
+	if created.Slug != "creme-brulee-basics" {
+		t.Fatalf("Unexpected slug '%s'", created.Slug)
+	}
+
+	req := httptest.NewRequest("GET", "/articles/by-slug/creme-brulee-basics", nil)
+	w := httptest.NewRecorder()
+	handler.GetArticleBySlug(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	mockRepo.UpdateArticle(created.ID, models.UpdateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Crème Brûlée Masterclass",
+		Body:     "Caramelise the sugar",
+	}, 0)
+
+	req = httptest.NewRequest("GET", "/articles/by-slug/creme-brulee-basics", nil)
+	w = httptest.NewRecorder()
+	handler.GetArticleBySlug(w, req)
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("Expected status code %d, got %d", http.StatusMovedPermanently, w.Code)
+	}
+	if location := w.Header().Get("Location"); location != "/articles/by-slug/creme-brulee-masterclass" {
+		t.Errorf("Unexpected redirect location '%s'", location)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/by-slug/unknown", nil)
+	w = httptest.NewRecorder()
+	handler.GetArticleBySlug(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestArticleHandler_Tags(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	jsonBody, _ := json.Marshal(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Tagged Article",
+		Body:     "Body",
+		Tags:     []string{" Go ", "go", "Web  Development"},
+	})
+	req := httptest.NewRequest("POST", "/articles", bytes.NewBuffer(jsonBody))
+	w := httptest.NewRecorder()
+	handler.CreateArticle(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	var article models.Article
+	if err := json.NewDecoder(w.Body).Decode(&article); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(article.Tags) != 2 || article.Tags[0] != "go" || article.Tags[1] != "web development" {
+		t.Errorf("Expected normalized tags [go web development], got %v", article.Tags)
+	}
+
+	req = httptest.NewRequest("GET", "/articles?tag=Go&tags_any=rust,go&tags_all=go&tags_all=web%20development", nil)
+	w = httptest.NewRecorder()
+	handler.ListArticles(w, req)
+
+	params := mockRepo.lastParams
+	if params.Tag != "go" || len(params.TagsAny) != 2 || len(params.TagsAll) != 2 {
+		t.Errorf("Unexpected tag filters: %+v", params)
+	}
+}
+
+func TestArticleHandler_ListArticles_RelevanceSort(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	req := httptest.NewRequest("GET", "/articles?sort=relevance", nil)
+	w := httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d without search, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/articles?search=%22go+patterns%22+-java&sort=relevance", nil)
+	w = httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if mockRepo.lastParams.Search != `"go patterns" -java` || !repository.SortsByRelevance(mockRepo.lastParams.Sort) {
+		t.Errorf("Unexpected list params: %+v", mockRepo.lastParams)
+	}
+}
+
+func TestArticleHandler_ListArticles_Cursor(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	mockRepo.articles = []models.ArticleListItem{
+		{ID: "article-2", Title: "Newer", CreatedAt: time.Now()},
+		{ID: "article-1", Title: "Older", CreatedAt: time.Now().Add(-time.Hour)},
+	}
+	handler := NewArticleHandler(mockRepo)
+
+	req := httptest.NewRequest("GET", "/articles?limit=2", nil)
+	w := httptest.NewRecorder()
+	handler.ListArticles(w, req)
+
+	next := w.Header().Get("X-Next-Cursor")
+	if next == "" {
+		t.Fatal("Expected X-Next-Cursor header")
+	}
+
+	// The cursor is opaque to clients and round-trips into keyset params
+	req = httptest.NewRequest("GET", "/articles?limit=2&cursor="+next, nil)
+	w = httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if position := mockRepo.lastParams.Cursor; position == nil || position.Values[1] != "article-1" || position.Backward {
+		t.Errorf("Expected forward cursor after article-1, got %+v", mockRepo.lastParams.Cursor)
+	}
+
+	// Tampered and foreign cursors are rejected
+	tampered := cursor.Encode(cursor.Cursor{Sort: "-created_at", Values: []string{"2025-09-04T10:30:00Z", "article-9"}}, "wrong-secret")
+	for _, token := range []string{tampered, "not-a-cursor"} {
+		req = httptest.NewRequest("GET", "/articles?cursor="+token, nil)
+		w = httptest.NewRecorder()
+		handler.ListArticles(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %q, got %d", http.StatusBadRequest, token, w.Code)
+		}
+	}
+}
+
+func TestArticleHandler_ListArticles_Sort(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	mockRepo.articles = []models.ArticleListItem{{ID: "article-1", Title: "Only", CreatedAt: time.Now()}}
+	handler := NewArticleHandler(mockRepo)
+
+	req := httptest.NewRequest("GET", "/articles?sort=author,-created_at", nil)
+	w := httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	expected := []repository.SortField{{Field: "author"}, {Field: "created_at", Desc: true}}
+	if !reflect.DeepEqual(mockRepo.lastParams.Sort, expected) {
+		t.Errorf("Expected sort %+v, got %+v", expected, mockRepo.lastParams.Sort)
+	}
+	next := w.Header().Get("X-Next-Cursor")
+
+	for _, query := range []string{"sort=body", "sort=-relevance", "sort=title,title", "sort=title&cursor=" + next} {
+		req = httptest.NewRequest("GET", "/articles?"+query, nil)
+		w = httptest.NewRecorder()
+		handler.ListArticles(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %q, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+func TestArticleHandler_ListArticles_Filters(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	req := httptest.NewRequest("GET", "/articles?created_after=2025-01-01T00:00:00Z&created_before=2025-02-01T00:00:00%2B07:00&author_id=author-1,author-2&author_id=author-3&exclude_author_id=author-2", nil)
+	w := httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	params := mockRepo.lastParams
+	if params.CreatedAfter == nil || !params.CreatedAfter.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected created_after %v", params.CreatedAfter)
+	}
+	if params.CreatedBefore == nil || !params.CreatedBefore.Equal(time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected created_before %v", params.CreatedBefore)
+	}
+	if !reflect.DeepEqual(params.AuthorIDs, []string{"author-1", "author-2", "author-3"}) || !reflect.DeepEqual(params.ExcludeAuthorIDs, []string{"author-2"}) {
+		t.Errorf("Unexpected author filters %v / %v", params.AuthorIDs, params.ExcludeAuthorIDs)
+	}
+}
+
+func TestArticleHandler_ListArticles_InvalidParams(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	req := httptest.NewRequest("GET", "/articles?created_after=yesterday&created_before=2025-13-01&sort=body&status=live", nil)
+	w := httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	names := []string{}
+	for _, param := range problem.InvalidParams {
+		names = append(names, param.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"sort", "status", "created_after", "created_before"}) {
+		t.Errorf("Expected every invalid parameter to be reported, got %v", names)
+	}
+
+	// An empty range is rejected against the upper bound
+	req = httptest.NewRequest("GET", "/articles?created_after=2025-02-01T00:00:00Z&created_before=2025-01-01T00:00:00Z", nil)
+	w = httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "created_before") {
+		t.Errorf("Expected a created_before error, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestArticleHandler_ListArticles_Fields(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	mockRepo.articles = []models.ArticleListItem{{
+		ID:        "article-1",
+		Title:     "Sparse",
+		Body:      "Full body",
+		CreatedAt: time.Now(),
+		WordCount: 250,
+		Author:    &models.Author{ID: "author-1", Name: "John Doe"},
+	}}
+	handler := NewArticleHandler(mockRepo)
+
+	req := httptest.NewRequest("GET", "/articles?fields=id,title,author.name,body", nil)
+	w := httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if !reflect.DeepEqual(mockRepo.lastParams.Fields, []string{"id", "title", "author.name", "body"}) {
+		t.Errorf("Unexpected fields %v", mockRepo.lastParams.Fields)
+	}
+
+	var items []map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&items); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	expected := map[string]interface{}{
+		"id":     "article-1",
+		"title":  "Sparse",
+		"body":   "Full body",
+		"author": map[string]interface{}{"name": "John Doe"},
+	}
+	if len(items) != 1 || !reflect.DeepEqual(items[0], expected) {
+		t.Errorf("Expected %v, got %v", expected, items)
+	}
+
+	// Binary formats keep the types of the selected fields
+	req = httptest.NewRequest("GET", "/articles?fields=id,word_count,created_at,author.name", nil)
+	req.Header.Set("Accept", "application/msgpack")
+	w = httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	var binary []map[string]interface{}
+	if err := codec.MessagePack.Decode(w.Body, &binary); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(binary) != 1 {
+		t.Fatalf("Expected one item, got %v", binary)
+	}
+	if createdAt, ok := binary[0]["created_at"].(time.Time); !ok || !createdAt.Equal(mockRepo.articles[0].CreatedAt) {
+		t.Errorf("Expected created_at as a timestamp, got %T %v", binary[0]["created_at"], binary[0]["created_at"])
+	}
+	if wordCount := reflect.ValueOf(binary[0]["word_count"]); !(wordCount.CanInt() && wordCount.Int() == 250 || wordCount.CanUint() && wordCount.Uint() == 250) {
+		t.Errorf("Expected word_count as an integer, got %T %v", binary[0]["word_count"], binary[0]["word_count"])
+	}
+	if len(binary[0]) != 4 {
+		t.Errorf("Expected only the selected fields, got %v", binary[0])
+	}
+
+	for _, query := range []string{"fields=id,password", "fields=id,snippet"} {
+		req = httptest.NewRequest("GET", "/articles?"+query, nil)
+		w = httptest.NewRecorder()
+		handler.ListArticles(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %q, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+func TestArticleHandler_ListArticles_Envelope(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	for i := 0; i < 3; i++ {
+		mockRepo.articles = append(mockRepo.articles, models.ArticleListItem{ID: fmt.Sprintf("article-%d", i), CreatedAt: time.Now()})
+	}
+	handler := NewArticleHandler(mockRepo)
+
+	// Without opting in the body stays a bare array, but Link headers are always set
+	req := httptest.NewRequest("GET", "/articles?page=2&limit=1", nil)
+	w := httptest.NewRecorder()
+	handler.ListArticles(w, req)
+
+	link := w.Header().Get("Link")
+	for _, expected := range []string{
+		`</articles?limit=1&page=1>; rel="first"`,
+		`</articles?limit=1&page=1>; rel="prev"`,
+		`</articles?limit=1&page=3>; rel="next"`,
+		`</articles?limit=1&page=3>; rel="last"`,
+	} {
+		if !strings.Contains(link, expected) {
+			t.Errorf("Expected Link header to contain %s, got %s", expected, link)
+		}
+	}
+	var bare []models.ArticleListItem
+	if err := json.NewDecoder(w.Body).Decode(&bare); err != nil {
+		t.Fatalf("Expected a bare array: %v", err)
+	}
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest("GET", "/articles?page=2&limit=1&envelope=true", nil),
+		func() *http.Request {
+			req := httptest.NewRequest("GET", "/articles?page=2&limit=1", nil)
+			req.Header.Set("Accept", `application/json; profile="urn:article-api:paginated"`)
+			return req
+		}(),
+	} {
+		w = httptest.NewRecorder()
+		handler.ListArticles(w, req)
+
+		var envelope struct {
+			Data  []models.ArticleListItem `json:"data"`
+			Meta  listMeta                 `json:"meta"`
+			Links listLinks                `json:"links"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&envelope); err != nil {
+			t.Fatalf("Failed to decode envelope: %v", err)
+		}
+		if len(envelope.Data) != 3 || envelope.Meta.Total != 3 || envelope.Meta.Page != 2 || envelope.Meta.NextCursor == "" {
+			t.Errorf("Unexpected envelope: %+v", envelope)
+		}
+		if envelope.Links.Next == "" || envelope.Links.Prev == "" || envelope.Links.Self != req.URL.RequestURI() {
+			t.Errorf("Unexpected envelope links: %+v", envelope.Links)
+		}
+	}
+
+	// An empty listing still has one page, in the header as in the envelope
+	mockRepo.articles = nil
+	req = httptest.NewRequest("GET", "/articles?envelope=true", nil)
+	w = httptest.NewRecorder()
+	handler.ListArticles(w, req)
+	var empty struct {
+		Meta listMeta `json:"meta"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&empty); err != nil {
+		t.Fatalf("Failed to decode envelope: %v", err)
+	}
+	if w.Header().Get("X-Total-Pages") != "1" || empty.Meta.TotalPages != 1 {
+		t.Errorf("Expected one page, got header %q and meta %+v", w.Header().Get("X-Total-Pages"), empty.Meta)
+	}
+}
+
+func TestArticleHandler_BatchCreateArticles(t *testing.T) {
+	batch := func(handler *ArticleHandler, body string) (int, []models.BatchItemResult) {
+		req := httptest.NewRequest("POST", "/articles/batch", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.BatchCreateArticles(w, req)
+
+		var results []models.BatchItemResult
+		json.NewDecoder(w.Body).Decode(&results)
+		return w.Code, results
+	}
+	statuses := func(results []models.BatchItemResult) []int {
+		codes := []int{}
+		for _, result := range results {
+			codes = append(codes, result.Status)
+		}
+		return codes
+	}
+
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	code, results := batch(handler, `{"articles": [
+		{"author_id": "author-1", "title": "First", "body": "One"},
+		{"author_id": "author-2", "title": "Second", "body": "Two"},
+		{"author_id": "author-1", "title": "Third", "body": "Three"}
+	]}`)
+	if code != http.StatusCreated || len(results) != 3 || results[1].ID != "test-article-2" {
+		t.Fatalf("Expected all items created, got %d: %+v", code, results)
+	}
+	if mockRepo.authorLookups != 1 {
+		t.Errorf("Expected authors to be looked up once, got %d lookups", mockRepo.authorLookups)
+	}
+
+	// Atomic batches create nothing when one item is invalid
+	invalid := `{"mode": "%s", "articles": [
+		{"author_id": "author-1", "title": "Valid", "body": "Body"},
+		{"author_id": "author-9", "title": "Unknown author", "body": "Body"},
+		{"author_id": "author-1", "title": "", "body": "Body"}
+	]}`
+	code, results = batch(handler, fmt.Sprintf(invalid, "atomic"))
+	if code != http.StatusMultiStatus || !reflect.DeepEqual(statuses(results), []int{424, 422, 422}) {
+		t.Errorf("Unexpected atomic results %d: %+v", code, results)
+	}
+
+	// Partial batches create every valid item
+	code, results = batch(handler, fmt.Sprintf(invalid, "partial"))
+	if code != http.StatusMultiStatus || !reflect.DeepEqual(statuses(results), []int{201, 422, 422}) {
+		t.Errorf("Unexpected partial results %d: %+v", code, results)
+	}
+
+	// A failing insert rolls back the rest of an atomic batch
+	mockRepo.failTitle = "Fifth"
+	code, results = batch(handler, `{"articles": [
+		{"author_id": "author-1", "title": "Fourth", "body": "Four"},
+		{"author_id": "author-2", "title": "Fifth", "body": "Five"}
+	]}`)
+	if code != http.StatusMultiStatus || !reflect.DeepEqual(statuses(results), []int{424, 500}) {
+		t.Errorf("Unexpected rollback results %d: %+v", code, results)
+	}
+	mockRepo.failTitle = ""
+
+	// Items duplicating an existing article are rejected like single creates
+	mockRepo.details["existing"] = &models.Article{ID: "existing", AuthorID: "author-1", Title: "Existing", Body: "Already here"}
+	duplicates := `{"mode": "partial", "articles": [
+		{"author_id": "author-1", "title": "existing", "body": "Another body"},
+		{"author_id": "author-1", "title": "Sixth", "body": "Six"}
+	]}`
+	code, results = batch(handler, duplicates)
+	if code != http.StatusMultiStatus || !reflect.DeepEqual(statuses(results), []int{409, 201}) {
+		t.Fatalf("Unexpected duplicate results %d: %+v", code, results)
+	}
+	if results[0].DuplicateOf == nil || results[0].DuplicateOf.Match != models.DuplicateMatchTitle {
+		t.Errorf("Expected the duplicate to be reported, got %+v", results[0])
+	}
+
+	// Only editors can force duplicates through
+	req := httptest.NewRequest("POST", "/articles/batch?force=true", strings.NewReader(duplicates))
+	w := httptest.NewRecorder()
+	handler.BatchCreateArticles(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status code %d for force without a key, got %d", http.StatusForbidden, w.Code)
+	}
+	handler.adminAPIKey = "admin-key"
+	req = httptest.NewRequest("POST", "/articles/batch?force=true", strings.NewReader(duplicates))
+	req.Header.Set("X-API-Key", "admin-key")
+	w = httptest.NewRecorder()
+	handler.BatchCreateArticles(w, req)
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected forced duplicates to be created, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, body := range []string{`{"articles": []}`, `{"mode": "eventual", "articles": [{}]}`, `not json`} {
+		if code, _ := batch(handler, body); code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusBadRequest, body, code)
+		}
+	}
+}
+
+func TestArticleHandler_ExportArticles(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockRepo.articles = []models.ArticleListItem{
+		{ID: "article-1", AuthorID: "author-1", Title: "First", Body: "Line one\nline two", CreatedAt: createdAt, Status: models.StatusPublished, Tags: []string{"go", "sql"}, Author: &models.Author{ID: "author-1", Name: "John Doe"}},
+		{ID: "article-2", AuthorID: "author-2", Title: "Second, again", Body: "Body", CreatedAt: createdAt, Status: models.StatusPublished, Author: &models.Author{ID: "author-2", Name: "Jane Smith"}},
+	}
+
+	// NDJSON is the default and carries the list filters through
+	req := httptest.NewRequest("GET", "/articles/export?author_id=author-1,author-2&sort=title", nil)
+	w := httptest.NewRecorder()
+	handler.ExportArticles(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("Expected NDJSON export, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %s", len(lines), w.Body.String())
+	}
+	var first models.Article
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || first.Body != "Line one\nline two" {
+		t.Errorf("Unexpected first line %s (%v)", lines[0], err)
+	}
+	if !reflect.DeepEqual(mockRepo.lastParams.AuthorIDs, []string{"author-1", "author-2"}) || repository.FormatSort(mockRepo.lastParams.Sort) != "title" {
+		t.Errorf("Expected list filters to be passed through, got %+v", mockRepo.lastParams)
+	}
+
+	// CSV quotes cells with separators and newlines
+	req = httptest.NewRequest("GET", "/articles/export?format=csv", nil)
+	w = httptest.NewRecorder()
+	handler.ExportArticles(w, req)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("Expected CSV export, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(w.Header().Get("Content-Disposition"), "articles.csv") {
+		t.Errorf("Unexpected Content-Disposition %q", w.Header().Get("Content-Disposition"))
+	}
+	expected := "id,author_id,author_name,title,slug,body,content_format,status,created_at,updated_at,published_at,tags,version\n" +
+		"article-1,author-1,John Doe,First,,\"Line one\nline two\",,published,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z,,\"go,sql\",1\n" +
+		"article-2,author-2,Jane Smith,\"Second, again\",,Body,,published,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z,,,1\n"
+	if w.Body.String() != expected {
+		t.Errorf("Unexpected CSV:\n%s", w.Body.String())
+	}
+
+	// JSON is a single array
+	req = httptest.NewRequest("GET", "/articles/export?format=json", nil)
+	w = httptest.NewRecorder()
+	handler.ExportArticles(w, req)
+	var articles []models.Article
+	if err := json.NewDecoder(w.Body).Decode(&articles); err != nil || len(articles) != 2 {
+		t.Errorf("Expected a JSON array of 2 articles, got %d (%v)", len(articles), err)
+	}
+
+	for _, query := range []string{"format=xml", "search=go&sort=relevance"} {
+		req = httptest.NewRequest("GET", "/articles/export?"+query, nil)
+		w = httptest.NewRecorder()
+		handler.ExportArticles(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+func TestArticleHandler_ExportArticles_ClientGone(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+	mockRepo.articles = []models.ArticleListItem{{ID: "article-1"}, {ID: "article-2"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest("GET", "/articles/export", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	handler.ExportArticles(w, req)
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected the export to stop once the client is gone, got %s", w.Body.String())
+	}
+}
+
+func TestArticleHandler_ExportArticles_Failure(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+	mockRepo.articles = []models.ArticleListItem{{ID: "article-1"}, {ID: "article-2"}}
+	mockRepo.exportErr = errors.New("connection lost")
+
+	// A failure after the headers aborts the response rather than ending it
+	// like a complete export
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Errorf("Expected the export to abort with http.ErrAbortHandler, got %v", recovered)
+		}
+	}()
+	req := httptest.NewRequest("GET", "/articles/export?format=json", nil)
+	handler.ExportArticles(httptest.NewRecorder(), req)
+}
+
+func TestArticleHandler_ContentFormat(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	body := `{"author_id": "author-1", "title": "Markdown", "body": "Hello **world** <script>alert(1)</script>", "content_format": "markdown"}`
+	req := httptest.NewRequest("POST", "/articles", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.CreateArticle(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d", http.StatusCreated, w.Code)
+	}
+	var created models.Article
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if created.ContentFormat != models.ContentFormatMarkdown || created.BodyHTML != "<p>Hello <strong>world</strong> </p>\n" {
+		t.Errorf("Expected sanitized markdown, got %q: %q", created.ContentFormat, created.BodyHTML)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/"+created.ID, nil)
+	w = httptest.NewRecorder()
+	handler.GetArticle(w, req)
+	var fetched models.Article
+	if err := json.NewDecoder(w.Body).Decode(&fetched); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if fetched.BodyHTML != created.BodyHTML {
+		t.Errorf("Expected body_html on the single-article response, got %q", fetched.BodyHTML)
+	}
+
+	// Switching to plain text escapes the body instead
+	req = httptest.NewRequest("PATCH", "/articles/"+created.ID, strings.NewReader(`{"content_format":"plain"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w = httptest.NewRecorder()
+	handler.PatchArticle(w, req)
+	var patched models.Article
+	if err := json.NewDecoder(w.Body).Decode(&patched); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if patched.ContentFormat != models.ContentFormatPlain || !strings.Contains(patched.BodyHTML, "**world** &lt;script&gt;") {
+		t.Errorf("Expected an escaped plain-text body, got %q: %q", patched.ContentFormat, patched.BodyHTML)
+	}
+
+	req = httptest.NewRequest("POST", "/articles", strings.NewReader(`{"author_id": "author-1", "title": "T", "body": "B", "content_format": "rtf"}`))
+	w = httptest.NewRecorder()
+	handler.CreateArticle(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for an unknown format, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestArticleHandler_RelatedArticles(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	created, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Getting Started with Go",
+		Body:     "Body",
+	})
+	mockRepo.related = map[string][]models.RelatedArticle{
+		created.ID: {
+			{ArticleListItem: models.ArticleListItem{ID: "article-2", Title: "Go Concurrency"}, Score: 1.4},
+			{ArticleListItem: models.ArticleListItem{ID: "article-3", Title: "Testing in Go"}, Score: 0.6},
+		},
+	}
+
+	req := httptest.NewRequest("GET", "/articles/"+created.ID+"/related", nil)
+	w := httptest.NewRecorder()
+	handler.RelatedArticles(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	var related []models.RelatedArticle
+	if err := json.NewDecoder(w.Body).Decode(&related); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(related) != 2 || related[0].ID != "article-2" || related[0].Score != 1.4 {
+		t.Errorf("Unexpected related articles %+v", related)
+	}
+	if mockRepo.relatedLimit != defaultRelatedLimit {
+		t.Errorf("Expected the default limit %d, got %d", defaultRelatedLimit, mockRepo.relatedLimit)
+	}
+
+	req = httptest.NewRequest("GET", "/articles/"+created.ID+"/related?limit=1", nil)
+	w = httptest.NewRecorder()
+	handler.RelatedArticles(w, req)
+	if err := json.NewDecoder(w.Body).Decode(&related); err != nil || len(related) != 1 {
+		t.Errorf("Expected 1 related article, got %+v (%v)", related, err)
+	}
+
+	for _, limit := range []string{"0", "21", "many"} {
+		req = httptest.NewRequest("GET", "/articles/"+created.ID+"/related?limit="+limit, nil)
+		w = httptest.NewRecorder()
+		handler.RelatedArticles(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for limit %s, got %d", http.StatusBadRequest, limit, w.Code)
+		}
+	}
+
+	// Articles without related ones get an empty list
+	mockRepo.related = nil
+	req = httptest.NewRequest("GET", "/articles/"+created.ID+"/related", nil)
+	w = httptest.NewRecorder()
+	handler.RelatedArticles(w, req)
+	if strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("Expected an empty list, got %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/articles/missing/related", nil)
+	w = httptest.NewRecorder()
+	handler.RelatedArticles(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestArticleHandler_CreateArticle_Duplicate(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+	handler.adminAPIKey = "admin-key"
+
+	existing, _ := mockRepo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Getting Started with Go",
+		Body:     "Original body",
+	})
+
+	body := `{"author_id": "author-1", "title": "  getting started   WITH go ", "body": "Another body"}`
+	req := httptest.NewRequest("POST", "/articles", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.CreateArticle(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status code %d, got %d", http.StatusConflict, w.Code)
+	}
+	var problem Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.DuplicateOf == nil || problem.DuplicateOf.ID != existing.ID || problem.DuplicateOf.Match != models.DuplicateMatchTitle {
+		t.Fatalf("Expected a title duplicate of %s, got %+v", existing.ID, problem.DuplicateOf)
+	}
+	if problem.DuplicateOf.Href != "/articles/"+existing.ID {
+		t.Errorf("Expected a link to the existing article, got %q", problem.DuplicateOf.Href)
+	}
+
+	// Another author may use the same title
+	req = httptest.NewRequest("POST", "/articles", strings.NewReader(`{"author_id": "author-2", "title": "Getting Started with Go", "body": "Original body"}`))
+	w = httptest.NewRecorder()
+	handler.CreateArticle(w, req)
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status code %d for another author, got %d", http.StatusCreated, w.Code)
+	}
+
+	// Forcing the duplicate requires the admin API key
+	req = httptest.NewRequest("POST", "/articles?force=true", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	handler.CreateArticle(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/articles?force=true", strings.NewReader(body))
+	req.Header.Set("X-API-Key", "admin-key")
+	w = httptest.NewRecorder()
+	handler.CreateArticle(w, req)
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status code %d, got %d", http.StatusCreated, w.Code)
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"article-api/internal/models"
+	"article-api/internal/repository"
+)
+
+// BatchCreateArticles handles POST /articles/batch. Every item is validated and
+// its author resolved up front; in atomic mode a single invalid, duplicate or
+// failing item keeps the whole batch from being created. The response lists one
+// result per item and is 201 when all items were created, 207 otherwise.
+func (h *ArticleHandler) BatchCreateArticles(w http.ResponseWriter, r *http.Request) {
+	var req models.BatchCreateArticlesRequest
+	if err := decodeRequest(r, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, invalidPayload(r))
+		return
+	}
+
+	switch req.Mode {
+	case "":
+		req.Mode = models.BatchModeAtomic
+	case models.BatchModeAtomic, models.BatchModePartial:
+	default:
+		writeProblem(w, r, http.StatusBadRequest, "mode must be one of atomic, partial")
+		return
+	}
+
+	if len(req.Articles) == 0 {
+		writeProblem(w, r, http.StatusBadRequest, "articles must contain at least one article")
+		return
+	}
+	if len(req.Articles) > h.batchMaxSize {
+		writeProblem(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("a batch can contain at most %d articles", h.batchMaxSize))
+		return
+	}
+
+	// Items are checked for duplicates like single creates, unless an editor
+	// sends force=true
+	force := r.URL.Query().Get("force") == "true"
+	if force && !h.isAdmin(r) {
+		writeProblem(w, r, http.StatusForbidden, "Creating duplicates with force=true requires a valid X-API-Key")
+		return
+	}
+
+	// Look up every referenced author at once
+	var authorIDs []string
+	seen := map[string]bool{}
+	for _, article := range req.Articles {
+		if article.AuthorID != "" && !seen[article.AuthorID] {
+			seen[article.AuthorID] = true
+			authorIDs = append(authorIDs, article.AuthorID)
+		}
+	}
+	authors, err := h.repo.GetAuthorsByIDs(authorIDs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to look up authors: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	results := make([]models.BatchItemResult, len(req.Articles))
+	var valid []int
+	firstInvalid := -1
+	for i := range req.Articles {
+		results[i].Index = i
+		err := req.Articles[i].Validate()
+		if err == nil && authors[req.Articles[i].AuthorID] == nil {
+			err = errors.New("Author not found")
+		}
+		if err != nil {
+			results[i].Status = http.StatusUnprocessableEntity
+			results[i].Error = err.Error()
+			if firstInvalid < 0 {
+				firstInvalid = i
+			}
+			continue
+		}
+		valid = append(valid, i)
+	}
+
+	atomic := req.Mode == models.BatchModeAtomic
+	if atomic && firstInvalid >= 0 {
+		for _, i := range valid {
+			results[i].Status = http.StatusFailedDependency
+			results[i].Error = fmt.Sprintf("not created because item %d is invalid", firstInvalid)
+		}
+		writeBatchResults(w, r, results)
+		return
+	}
+
+	reqs := make([]models.CreateArticleRequest, len(valid))
+	for j, i := range valid {
+		reqs[j] = req.Articles[i]
+	}
+	articles, errs, err := h.repo.CreateArticles(reqs, atomic, !force)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create articles: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	for j, i := range valid {
+		var aborted *repository.BatchAbortedError
+		var duplicate *repository.DuplicateArticleError
+		switch {
+		case errs[j] == nil:
+			results[i].Status = http.StatusCreated
+			results[i].ID = articles[j].ID
+			results[i].Slug = articles[j].Slug
+		case errors.As(errs[j], &duplicate):
+			results[i].Status = http.StatusConflict
+			results[i].Error = describeDuplicate(duplicate.Duplicate)
+			results[i].DuplicateOf = duplicate.Duplicate
+		case errors.As(errs[j], &aborted):
+			results[i].Status = http.StatusFailedDependency
+			results[i].Error = fmt.Sprintf("rolled back because item %d failed", valid[aborted.Index])
+		default:
+			results[i].Status = http.StatusInternalServerError
+			results[i].Error = fmt.Sprintf("Failed to create article: %v", errs[j])
+		}
+	}
+
+	writeBatchResults(w, r, results)
+}
+
+// writeBatchResults writes per-item batch results, with 201 when every item was
+// created and 207 Multi-Status otherwise
+func writeBatchResults(w http.ResponseWriter, r *http.Request, results []models.BatchItemResult) {
+	status := http.StatusCreated
+	for _, result := range results {
+		if result.Status != http.StatusCreated {
+			status = http.StatusMultiStatus
+			break
+		}
+	}
+
+	if err := writeEncoded(w, r, status, results); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
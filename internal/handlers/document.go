@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"article-api/internal/cache"
+)
+
+// publicBaseURL returns the configured base URL of the API without a trailing slash
+func publicBaseURL(configured string) string {
+	return strings.TrimRight(configured, "/")
+}
+
+// requireBaseURL answers 503 unless a base URL is configured, reporting whether
+// one is. Feeds and sitemaps link absolutely and are cached, so their links
+// never come from the client-supplied Host header.
+func requireBaseURL(w http.ResponseWriter, r *http.Request, base string) bool {
+	if base != "" {
+		return true
+	}
+	writeProblem(w, r, http.StatusServiceUnavailable, "Feeds and sitemaps require BASE_URL to be configured")
+	return false
+}
+
+// cachedDocument is a rendered feed or sitemap with its validators
+type cachedDocument struct {
+	Body     []byte    `json:"body"`
+	ETag     string    `json:"etag"`
+	Modified time.Time `json:"modified"`
+}
+
+// renderCached returns the document cached under key, calling render and
+// caching its output on a miss. render returns the body and the time the
+// underlying content last changed.
+func renderCached(store cache.CacheServiceInterface, key string, ttl int, render func() ([]byte, time.Time, error)) (cachedDocument, error) {
+	var doc cachedDocument
+	if err := store.Get(key, &doc); err == nil {
+		return doc, nil
+	}
+
+	body, modified, err := render()
+	if err != nil {
+		return doc, err
+	}
+
+	sum := sha256.Sum256(body)
+	doc = cachedDocument{Body: body, ETag: fmt.Sprintf("\"%x\"", sum[:16]), Modified: modified}
+	if cacheErr := store.SetWithTTL(key, doc, ttl); cacheErr != nil {
+		log.Printf("Failed to cache %s: %v", key, cacheErr)
+	}
+	return doc, nil
+}
+
+// writeCachedDocument writes a rendered document, answering conditional
+// requests from its ETag and modification time
+func writeCachedDocument(w http.ResponseWriter, r *http.Request, doc cachedDocument, contentType string, maxAge int) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", doc.ETag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	http.ServeContent(w, r, "", doc.Modified, bytes.NewReader(doc.Body))
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"article-api/internal/codec"
+)
+
+// responseCodec returns the response format negotiated from the Accept header,
+// or JSON when none is acceptable so errors can still be reported
+func responseCodec(r *http.Request) *codec.Codec {
+	if c, ok := codec.Negotiate(r.Header.Get("Accept")); ok {
+		return c
+	}
+	return codec.JSON
+}
+
+// requestCodec returns the format of the request body named by its
+// Content-Type, or JSON when there is none or it is unsupported
+func requestCodec(r *http.Request) *codec.Codec {
+	if c, ok := codec.ForContentType(r.Header.Get("Content-Type")); ok {
+		return c
+	}
+	return codec.JSON
+}
+
+// decodeRequest decodes the request body into v in the format of its Content-Type
+func decodeRequest(r *http.Request, v interface{}) error {
+	return requestCodec(r).Decode(r.Body, v)
+}
+
+// invalidPayload describes a request body that could not be decoded
+func invalidPayload(r *http.Request) string {
+	return fmt.Sprintf("Invalid %s payload", requestCodec(r).Name())
+}
+
+// writeEncoded writes v with the status in the format negotiated for the
+// request. It returns an error only when v cannot be encoded, in which case
+// nothing has been written yet.
+func writeEncoded(w http.ResponseWriter, r *http.Request, status int, v interface{}) error {
+	c := responseCodec(r)
+	return writeCodec(w, c, c.MediaType(), status, v)
+}
+
+// writeCodec writes v with the status in the given format, labelled with
+// contentType. The body is encoded before anything is written.
+func writeCodec(w http.ResponseWriter, c *codec.Codec, contentType string, status int, v interface{}) error {
+	var body bytes.Buffer
+	if err := c.Encode(&body, v); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+	w.Write(body.Bytes())
+	return nil
+}
+
+// Negotiate checks that a handler can speak the client's formats before it
+// runs: requests accepting none of the response formats get 406 and request
+// bodies in a format that cannot be decoded get 415. A 406 is reported as JSON.
+func Negotiate(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		supported := strings.Join(codec.MediaTypes(), ", ")
+
+		if _, ok := codec.Negotiate(r.Header.Get("Accept")); !ok {
+			writeProblem(w, r, http.StatusNotAcceptable, "Responses are available as "+supported)
+			return
+		}
+
+		if r.ContentLength != 0 {
+			if _, ok := codec.ForContentType(r.Header.Get("Content-Type")); !ok {
+				writeProblem(w, r, http.StatusUnsupportedMediaType, "Request bodies must be one of "+supported)
+				return
+			}
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"article-api/internal/codec"
+	"article-api/internal/models"
+)
+
+func TestNegotiate(t *testing.T) {
+	handled := false
+	handler := Negotiate(func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		body        string
+		expected    int
+	}{
+		{"defaults", "", "", "", http.StatusNoContent},
+		{"acceptable", "application/cbor", "application/xml", "<a/>", http.StatusNoContent},
+		{"not acceptable", "text/html", "", "", http.StatusNotAcceptable},
+		{"unsupported body", "", "text/plain", "hello", http.StatusUnsupportedMediaType},
+		{"content type without body", "", "text/plain", "", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = false
+			req := httptest.NewRequest("POST", "/articles", strings.NewReader(tt.body))
+			req.Header.Set("Accept", tt.accept)
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			if w.Code != tt.expected {
+				t.Fatalf("Expected status code %d, got %d", tt.expected, w.Code)
+			}
+			if handled != (tt.expected == http.StatusNoContent) {
+				t.Errorf("Expected the handler to run only for acceptable requests")
+			}
+			if tt.expected == http.StatusNotAcceptable && w.Header().Get("Content-Type") != "application/problem+json" {
+				t.Errorf("Expected a JSON problem, got %q", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestArticleHandler_ContentNegotiation(t *testing.T) {
+	mockRepo := NewMockArticleRepository()
+	handler := NewArticleHandler(mockRepo)
+
+	// Create an article from XML and read the response as MessagePack
+	body := `<article><author_id>author-1</author_id><title>Binary Formats</title>` +
+		`<body>Compact bodies</body><tags><item>Go</item><item>Encoding</item></tags></article>`
+	req := httptest.NewRequest("POST", "/articles", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/msgpack")
+	w := httptest.NewRecorder()
+	handler.CreateArticle(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != "application/msgpack" || w.Header().Get("Vary") != "Accept" {
+		t.Errorf("Expected a MessagePack response varying by Accept, got %q, %q", w.Header().Get("Content-Type"), w.Header().Get("Vary"))
+	}
+	var created models.Article
+	if err := codec.MessagePack.Decode(w.Body, &created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if created.Title != "Binary Formats" || strings.Join(created.Tags, ",") != "go,encoding" {
+		t.Errorf("Unexpected article %+v", created)
+	}
+
+	// The same article as CBOR and XML
+	for _, c := range []*codec.Codec{codec.CBOR, codec.XML} {
+		req = httptest.NewRequest("GET", "/articles/"+created.ID, nil)
+		req.Header.Set("Accept", c.MediaType())
+		w = httptest.NewRecorder()
+		handler.GetArticle(w, req)
+
+		var fetched models.Article
+		if err := c.Decode(w.Body, &fetched); err != nil {
+			t.Fatalf("Failed to decode %s response: %v", c.Name(), err)
+		}
+		if fetched.ID != created.ID || !fetched.CreatedAt.Equal(created.CreatedAt) || fetched.Author == nil {
+			t.Errorf("Unexpected %s article %+v", c.Name(), fetched)
+		}
+	}
+
+	// Update from CBOR
+	var update bytes.Buffer
+	codec.CBOR.Encode(&update, models.UpdateArticleRequest{AuthorID: "author-1", Title: "Updated", Body: "Body"})
+	req = httptest.NewRequest("PUT", "/articles/"+created.ID, &update)
+	req.Header.Set("Content-Type", "application/cbor")
+	w = httptest.NewRecorder()
+	handler.UpdateArticle(w, req)
+	var updated models.Article
+	if err := json.NewDecoder(w.Body).Decode(&updated); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if updated.Title != "Updated" {
+		t.Errorf("Expected the CBOR update to apply, got %+v", updated)
+	}
+
+	// Problems follow the negotiated format
+	req = httptest.NewRequest("PUT", "/articles/"+created.ID, strings.NewReader("<article><title>Broken"))
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/xml")
+	w = httptest.NewRecorder()
+	handler.UpdateArticle(w, req)
+	if w.Header().Get("Content-Type") != "application/problem+xml" {
+		t.Fatalf("Expected an XML problem, got %q", w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(w.Body.String(), `<problem xmlns="urn:ietf:rfc:7807">`) || !strings.Contains(w.Body.String(), "Invalid XML payload") {
+		t.Errorf("Unexpected problem %s", w.Body.String())
+	}
+}
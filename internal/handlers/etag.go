@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"article-api/internal/codec"
+)
+
+// errWeakIfMatch rejects weak entity tags, which If-Match never matches (RFC 9110)
+var errWeakIfMatch = errors.New("If-Match requires a strong entity tag")
+
+// articleETag returns the strong entity tag of an article version in the
+// format negotiated for the request. JSON keeps the bare version; other
+// formats append their media subtype, e.g. "3+xml", so each representation
+// has its own tag.
+func articleETag(r *http.Request, version int) string {
+	c := responseCodec(r)
+	if c == codec.JSON {
+		return fmt.Sprintf("\"%d\"", version)
+	}
+	return fmt.Sprintf("\"%d+%s\"", version, formatSuffix(c))
+}
+
+// formatSuffix returns the media subtype an entity tag names a format by
+func formatSuffix(c *codec.Codec) string {
+	_, subtype, _ := strings.Cut(c.MediaType(), "/")
+	return subtype
+}
+
+// parseIfMatch extracts the expected article version from an If-Match header.
+// An empty header or "*" returns 0, meaning no version precondition. The tag
+// of any representation of the version matches; weak tags return errWeakIfMatch.
+func parseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		return 0, fmt.Errorf("If-Match must contain a single entity tag")
+	}
+	if strings.HasPrefix(header, "W/") {
+		return 0, errWeakIfMatch
+	}
+
+	if len(header) < 2 || !strings.HasPrefix(header, "\"") || !strings.HasSuffix(header, "\"") {
+		return 0, fmt.Errorf("If-Match must be a quoted entity tag")
+	}
+
+	tag, suffix, hasSuffix := strings.Cut(header[1:len(header)-1], "+")
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 || (hasSuffix && !knownFormatSuffix(suffix)) {
+		return 0, fmt.Errorf("If-Match does not reference a known article version")
+	}
+	return version, nil
+}
+
+// knownFormatSuffix reports whether an entity tag suffix names a response format
+func knownFormatSuffix(suffix string) bool {
+	for _, c := range []*codec.Codec{codec.XML, codec.MessagePack, codec.CBOR} {
+		if formatSuffix(c) == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// writeIfMatchError reports an unusable If-Match header: 412 for a weak tag,
+// which can never match, and 400 for a malformed one
+func writeIfMatchError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, errWeakIfMatch) {
+		status = http.StatusPreconditionFailed
+	}
+	writeProblem(w, r, status, err.Error())
+}
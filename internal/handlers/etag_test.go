@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestArticleETag(t *testing.T) {
+	tests := map[string]string{
+		"":                    `"3"`,
+		"application/json":    `"3"`,
+		"application/xml":     `"3+xml"`,
+		"application/msgpack": `"3+msgpack"`,
+		"application/cbor":    `"3+cbor"`,
+	}
+
+	for accept, expected := range tests {
+		req := httptest.NewRequest("GET", "/articles/article-1", nil)
+		req.Header.Set("Accept", accept)
+		etag := articleETag(req, 3)
+		if etag != expected {
+			t.Errorf("Accept %q: expected ETag %s, got %s", accept, expected, etag)
+		}
+		if version, err := parseIfMatch(etag); err != nil || version != 3 {
+			t.Errorf("Expected %s to match version 3, got %d, %v", etag, version, err)
+		}
+	}
+}
+
+func TestParseIfMatch(t *testing.T) {
+	for _, header := range []string{"", "*"} {
+		if version, err := parseIfMatch(header); err != nil || version != 0 {
+			t.Errorf("parseIfMatch(%q) = %d, %v, expected no precondition", header, version, err)
+		}
+	}
+
+	if _, err := parseIfMatch(`W/"3"`); !errors.Is(err, errWeakIfMatch) {
+		t.Errorf("Expected weak tags to be rejected, got %v", err)
+	}
+
+	for _, header := range []string{`3`, `"3", "4"`, `"0"`, `"abc"`, `"3+html"`} {
+		if _, err := parseIfMatch(header); err == nil || errors.Is(err, errWeakIfMatch) {
+			t.Errorf("parseIfMatch(%q): expected a malformed tag error, got %v", header, err)
+		}
+	}
+}
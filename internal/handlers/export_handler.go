@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"article-api/internal/models"
+	"article-api/internal/repository"
+)
+
+// exportFlushEvery is the number of articles written between flushes, so
+// clients see progress without a flush per row
+const exportFlushEvery = 100
+
+// exportCSVHeader names the columns of a CSV export; tags are comma-separated
+// within their cell and timestamps are RFC 3339
+var exportCSVHeader = []string{
+	"id", "author_id", "author_name", "title", "slug", "body", "content_format",
+	"status", "created_at", "updated_at", "published_at", "tags", "version",
+}
+
+// exportWriter encodes a stream of articles in one export format
+type exportWriter interface {
+	begin() error
+	write(article *models.Article) error
+	end() error
+	// flush pushes out anything the writer buffers itself
+	flush()
+}
+
+// ExportArticles handles GET /articles/export. It accepts the filters and sort
+// of the list endpoint and streams every matching article, without paging, as
+// NDJSON (the default), CSV or a JSON array. The stream stops as soon as the
+// client goes away.
+func (h *ArticleHandler) ExportArticles(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "ndjson"
+	}
+
+	var out exportWriter
+	var contentType string
+	switch format {
+	case "ndjson":
+		out, contentType = &ndjsonExport{encoder: json.NewEncoder(w)}, "application/x-ndjson"
+	case "csv":
+		out, contentType = &csvExport{writer: csv.NewWriter(w)}, "text/csv; charset=utf-8"
+	case "json":
+		out, contentType = &jsonExport{w: w}, "application/json"
+	default:
+		writeInvalidParams(w, r, []InvalidParam{{Name: "format", Reason: "must be one of ndjson, csv, json"}})
+		return
+	}
+
+	params, ok := h.parseListParams(w, r)
+	if !ok {
+		return
+	}
+	if repository.SortsByRelevance(params.Sort) {
+		writeInvalidParams(w, r, []InvalidParam{{Name: "sort", Reason: "relevance is not supported for exports"}})
+		return
+	}
+
+	// Exports outlive the server write timeout, so lift it for this response
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="articles.%s"`, format))
+	w.WriteHeader(http.StatusOK)
+
+	flush := func() {
+		out.flush()
+		controller.Flush()
+	}
+
+	// Headers are already sent, so a failure aborts the response instead of
+	// ending it cleanly; clients then see a broken stream rather than a
+	// complete-looking but truncated export
+	if err := out.begin(); err != nil {
+		abortExport(r, 0, err)
+		return
+	}
+	written := 0
+	err := h.repo.ExportArticles(r.Context(), params, func(article *models.Article) error {
+		if err := out.write(article); err != nil {
+			return err
+		}
+		written++
+		if written%exportFlushEvery == 0 {
+			flush()
+		}
+		return nil
+	})
+	if err != nil {
+		abortExport(r, written, err)
+		return
+	}
+	if err := out.end(); err != nil {
+		abortExport(r, written, err)
+		return
+	}
+	flush()
+}
+
+// abortExport ends a streaming export that failed after written articles. The
+// connection is reset without terminating the response, so the client cannot
+// mistake the partial output for a complete export. Once the client is gone
+// there is nobody to tell, and it returns for the handler to stop.
+func abortExport(r *http.Request, written int, err error) {
+	if r.Context().Err() != nil {
+		return
+	}
+	log.Printf("Article export failed after %d articles: %v", written, err)
+	panic(http.ErrAbortHandler)
+}
+
+// ndjsonExport writes one article JSON object per line
+type ndjsonExport struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonExport) begin() error { return nil }
+
+func (e *ndjsonExport) write(article *models.Article) error { return e.encoder.Encode(article) }
+
+func (e *ndjsonExport) end() error { return nil }
+
+func (e *ndjsonExport) flush() {}
+
+// csvExport writes a header row followed by one row per article
+type csvExport struct {
+	writer *csv.Writer
+}
+
+func (e *csvExport) begin() error { return e.writer.Write(exportCSVHeader) }
+
+func (e *csvExport) write(article *models.Article) error {
+	authorName := ""
+	if article.Author != nil {
+		authorName = article.Author.Name
+	}
+	publishedAt := ""
+	if article.PublishedAt != nil {
+		publishedAt = article.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	return e.writer.Write([]string{
+		article.ID,
+		article.AuthorID,
+		authorName,
+		article.Title,
+		article.Slug,
+		article.Body,
+		article.ContentFormat,
+		article.Status,
+		article.CreatedAt.UTC().Format(time.RFC3339),
+		article.UpdatedAt.UTC().Format(time.RFC3339),
+		publishedAt,
+		strings.Join(article.Tags, ","),
+		strconv.Itoa(article.Version),
+	})
+}
+
+func (e *csvExport) end() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvExport) flush() { e.writer.Flush() }
+
+// jsonExport writes a single JSON array, one element at a time
+type jsonExport struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (e *jsonExport) begin() error {
+	_, err := e.w.Write([]byte("["))
+	return err
+}
+
+func (e *jsonExport) write(article *models.Article) error {
+	data, err := json.Marshal(article)
+	if err != nil {
+		return err
+	}
+	if e.started {
+		if _, err := e.w.Write([]byte(",\n")); err != nil {
+			return err
+		}
+	}
+	e.started = true
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExport) end() error {
+	_, err := e.w.Write([]byte("]\n"))
+	return err
+}
+
+func (e *jsonExport) flush() {}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"article-api/internal/cache"
+	"article-api/internal/config"
+	"article-api/internal/cursor"
+	"article-api/internal/feed"
+	"article-api/internal/models"
+	"article-api/internal/render"
+	"article-api/internal/repository"
+)
+
+// Feed output formats
+const (
+	feedAtom = "atom"
+	feedRSS  = "rss"
+	feedJSON = "json"
+)
+
+// feedContentTypes maps each feed format to its media type
+var feedContentTypes = map[string]string{
+	feedAtom: "application/atom+xml; charset=utf-8",
+	feedRSS:  "application/rss+xml; charset=utf-8",
+	feedJSON: "application/feed+json",
+}
+
+// feedFields are the list fields an entry is built from
+var feedFields = []string{
+	"id", "title", "slug", "excerpt", "created_at", "updated_at", "published_at", "tags", "author.id", "author.name",
+}
+
+// jsonFeedFields add the body, its format and the version keying its cached
+// rendering, since JSON Feed items carry the full content
+var jsonFeedFields = append(append([]string{}, feedFields...), "body", "content_format", "version")
+
+// FeedHandler serves syndication feeds of published articles
+type FeedHandler struct {
+	repo         repository.ArticleRepositoryInterface
+	cache        cache.CacheServiceInterface
+	cacheTTL     int
+	size         int
+	cursorSecret string
+	publicURL    string
+}
+
+// NewFeedHandler creates a new feed handler
+func NewFeedHandler(repo repository.ArticleRepositoryInterface, cacheService cache.CacheServiceInterface) *FeedHandler {
+	cfg := config.LoadConfig()
+
+	return &FeedHandler{
+		repo:         repo,
+		cache:        cacheService,
+		cacheTTL:     cfg.Redis.ListTTL,
+		size:         cfg.App.FeedSize,
+		cursorSecret: cfg.App.CursorSecret,
+		publicURL:    publicBaseURL(cfg.App.BaseURL),
+	}
+}
+
+// ArticlesAtom handles GET /feeds/articles.atom
+func (h *FeedHandler) ArticlesAtom(w http.ResponseWriter, r *http.Request) {
+	h.articlesFeed(w, r, feedAtom)
+}
+
+// ArticlesRSS handles GET /feeds/articles.rss
+func (h *FeedHandler) ArticlesRSS(w http.ResponseWriter, r *http.Request) {
+	h.articlesFeed(w, r, feedRSS)
+}
+
+// articlesFeed serves the feed of all published articles
+func (h *FeedHandler) articlesFeed(w http.ResponseWriter, r *http.Request, format string) {
+	base := h.publicURL
+	h.serveFeed(w, r, format, "articles", func() (*feed.Feed, error) {
+		return h.buildFeed(r, repository.ListArticlesParams{}, feed.Feed{
+			ID:          "urn:article-api:feed:articles",
+			Title:       "Articles",
+			Description: "The latest published articles",
+			Link:        base + "/articles",
+		})
+	})
+}
+
+// ArticlesJSON handles GET /feeds/articles.json, a JSON Feed of published
+// articles with their full content. Older articles are paged through next_url,
+// which carries a signed cursor.
+func (h *FeedHandler) ArticlesJSON(w http.ResponseWriter, r *http.Request) {
+	params := repository.ListArticlesParams{Fields: jsonFeedFields, Sort: repository.DefaultSort}
+	token := r.URL.Query().Get("cursor")
+	if token != "" {
+		c, err := cursor.Decode(token, h.cursorSecret)
+		if err != nil || c.Backward || c.Sort != repository.FormatSort(params.Sort) {
+			writeInvalidParams(w, r, []InvalidParam{{Name: "cursor", Reason: "is not a valid feed cursor"}})
+			return
+		}
+		params.Cursor = &c
+	}
+
+	base := h.publicURL
+	h.serveFeed(w, r, feedJSON, "articles:"+token, func() (*feed.Feed, error) {
+		return h.buildFeed(r, params, feed.Feed{
+			ID:          "urn:article-api:feed:articles",
+			Title:       "Articles",
+			Description: "The latest published articles",
+			Link:        base + "/articles",
+		})
+	})
+}
+
+// AuthorFeed handles GET /authors/{id}/feed.atom
+func (h *FeedHandler) AuthorFeed(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/authors/")
+	id := segments[0]
+
+	author, err := h.repo.GetAuthorByID(id)
+	var notFound *repository.AuthorNotFoundError
+	if errors.As(err, &notFound) {
+		writeProblem(w, r, http.StatusNotFound, "Author not found")
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get author: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	base := h.publicURL
+	h.serveFeed(w, r, feedAtom, "author:"+id, func() (*feed.Feed, error) {
+		return h.buildFeed(r, repository.ListArticlesParams{AuthorIDs: []string{id}}, feed.Feed{
+			ID:          "urn:article-api:feed:author:" + url.PathEscape(id),
+			Title:       "Articles by " + author.Name,
+			Description: "The latest published articles by " + author.Name,
+			Link:        base + "/articles?author_id=" + url.QueryEscape(id),
+		})
+	})
+}
+
+// TagFeed handles GET /tags/{name}/feed.atom
+func (h *FeedHandler) TagFeed(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/tags/")
+	tag := models.NormalizeTag(segments[0])
+	if tag == "" {
+		writeProblem(w, r, http.StatusNotFound, "Tag not found")
+		return
+	}
+
+	base := h.publicURL
+	h.serveFeed(w, r, feedAtom, "tag:"+tag, func() (*feed.Feed, error) {
+		return h.buildFeed(r, repository.ListArticlesParams{Tag: tag}, feed.Feed{
+			ID:          "urn:article-api:feed:tag:" + url.PathEscape(tag),
+			Title:       "Articles tagged " + tag,
+			Description: "The latest published articles tagged " + tag,
+			Link:        base + "/articles?tag=" + url.QueryEscape(tag),
+		})
+	})
+}
+
+// buildFeed fills a feed with the newest published articles matching params
+func (h *FeedHandler) buildFeed(r *http.Request, params repository.ListArticlesParams, f feed.Feed) (*feed.Feed, error) {
+	base := h.publicURL
+	params.Limit = h.size
+	params.Page = 1
+	params.Status = models.StatusPublished
+	if len(params.Fields) == 0 {
+		params.Fields = feedFields
+	}
+
+	result, err := h.repo.ListArticles(params)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, article := range result.Articles {
+		entry := feed.Entry{
+			ID:         "urn:article-api:article:" + article.ID,
+			Title:      article.Title,
+			Link:       base + "/articles/by-slug/" + url.PathEscape(article.Slug),
+			Summary:    article.Excerpt,
+			Published:  article.CreatedAt,
+			Updated:    article.CreatedAt,
+			Categories: article.Tags,
+		}
+		if article.Body != "" {
+			if entry.Content, err = render.PlainText(article.Body, article.ContentFormat); err != nil {
+				return nil, err
+			}
+			entry.ContentHTML, err = h.repo.RenderArticleBody(&models.Article{
+				ID:            article.ID,
+				Version:       article.Version,
+				Body:          article.Body,
+				ContentFormat: article.ContentFormat,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if article.PublishedAt != nil {
+			entry.Published = *article.PublishedAt
+		}
+		if article.UpdatedAt != nil {
+			entry.Updated = *article.UpdatedAt
+		}
+		if article.Author != nil {
+			entry.Author = article.Author.Name
+			entry.AuthorURL = base + "/authors/" + url.PathEscape(article.Author.ID) + "/feed.atom"
+		}
+		f.Entries = append(f.Entries, entry)
+	}
+
+	f.Self = base + r.URL.Path
+	if result.NextCursor != nil {
+		f.NextURL = f.Self + "?cursor=" + url.QueryEscape(cursor.Encode(*result.NextCursor, h.cursorSecret))
+	}
+	f.Updated = feed.LastUpdated(f.Entries)
+	return &f, nil
+}
+
+// serveFeed writes a feed in the given format, rendering it on a cache miss.
+// Rendered feeds are dropped with the listings whenever an article changes.
+// Conditional requests are answered from the cached validators.
+func (h *FeedHandler) serveFeed(w http.ResponseWriter, r *http.Request, format, name string, build func() (*feed.Feed, error)) {
+	if !requireBaseURL(w, r, h.publicURL) {
+		return
+	}
+	key := repository.FeedCacheKey(fmt.Sprintf("%s:%s", format, name))
+
+	doc, err := renderCached(h.cache, key, h.cacheTTL, func() ([]byte, time.Time, error) {
+		f, err := build()
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		var body []byte
+		switch format {
+		case feedRSS:
+			body, err = f.RSS()
+		case feedJSON:
+			body, err = f.JSON()
+		default:
+			body, err = f.Atom()
+		}
+		return body, f.Updated, err
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to build feed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeCachedDocument(w, r, doc, feedContentTypes[format], h.cacheTTL)
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"article-api/internal/cache"
+	"article-api/internal/models"
+)
+
+func newFeedTestHandler() (*FeedHandler, *MockArticleRepository) {
+	mockRepo := NewMockArticleRepository()
+	updatedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockRepo.articles = []models.ArticleListItem{
+		{
+			ID:        "article-1",
+			AuthorID:  "author-1",
+			Title:     "Feeds & Things",
+			Slug:      "feeds-things",
+			Excerpt:   "A short excerpt",
+			CreatedAt: updatedAt.Add(-time.Hour),
+			UpdatedAt: &updatedAt,
+			Status:    models.StatusPublished,
+			Tags:      []string{"go"},
+			Author:    &models.Author{ID: "author-1", Name: "John Doe"},
+		},
+	}
+	handler := NewFeedHandler(mockRepo, cache.NewMockCacheService())
+	handler.publicURL = "http://example.com"
+	return handler, mockRepo
+}
+
+func TestFeedHandler_RequiresBaseURL(t *testing.T) {
+	handler, _ := newFeedTestHandler()
+	handler.publicURL = ""
+
+	// Links are never taken from the Host header
+	req := httptest.NewRequest("GET", "http://attacker.example/feeds/articles.atom", nil)
+	w := httptest.NewRecorder()
+	handler.ArticlesAtom(w, req)
+	if w.Code != http.StatusServiceUnavailable || strings.Contains(w.Body.String(), "attacker.example/articles") {
+		t.Errorf("Expected status code %d, got %d\n%s", http.StatusServiceUnavailable, w.Code, w.Body.String())
+	}
+}
+
+func TestFeedHandler_ArticlesAtom(t *testing.T) {
+	handler, mockRepo := newFeedTestHandler()
+
+	req := httptest.NewRequest("GET", "http://example.com/feeds/articles.atom", nil)
+	w := httptest.NewRecorder()
+	handler.ArticlesAtom(w, req)
+
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/atom+xml") {
+		t.Fatalf("Expected an Atom feed, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	for _, expected := range []string{
+		"<id>urn:article-api:article:article-1</id>",
+		"<title>Feeds &amp; Things</title>",
+		`href="http://example.com/articles/by-slug/feeds-things"`,
+		`href="http://example.com/feeds/articles.atom"`,
+		"<updated>2025-01-02T03:04:05Z</updated>",
+		"<summary>A short excerpt</summary>",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected feed to contain %s, got\n%s", expected, body)
+		}
+	}
+	if mockRepo.lastParams.Status != models.StatusPublished || mockRepo.lastParams.Limit != handler.size {
+		t.Errorf("Expected the newest published articles, got %+v", mockRepo.lastParams)
+	}
+	if w.Header().Get("Last-Modified") != "Thu, 02 Jan 2025 03:04:05 GMT" {
+		t.Errorf("Unexpected Last-Modified %q", w.Header().Get("Last-Modified"))
+	}
+
+	// Conditional requests are answered with 304
+	etag := w.Header().Get("ETag")
+	req = httptest.NewRequest("GET", "http://example.com/feeds/articles.atom", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ArticlesAtom(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotModified, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "http://example.com/feeds/articles.atom", nil)
+	req.Header.Set("If-Modified-Since", "Thu, 02 Jan 2025 03:04:05 GMT")
+	w = httptest.NewRecorder()
+	handler.ArticlesAtom(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotModified, w.Code)
+	}
+
+	// Rendered feeds are served from the cache until it is invalidated
+	mockRepo.articles = nil
+	req = httptest.NewRequest("GET", "http://example.com/feeds/articles.atom", nil)
+	w = httptest.NewRecorder()
+	handler.ArticlesAtom(w, req)
+	if w.Header().Get("ETag") != etag || !strings.Contains(w.Body.String(), "article-1") {
+		t.Errorf("Expected the cached feed, got\n%s", w.Body.String())
+	}
+}
+
+func TestFeedHandler_ArticlesRSS(t *testing.T) {
+	handler, _ := newFeedTestHandler()
+
+	req := httptest.NewRequest("GET", "http://example.com/feeds/articles.rss", nil)
+	w := httptest.NewRecorder()
+	handler.ArticlesRSS(w, req)
+
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/rss+xml") {
+		t.Fatalf("Expected an RSS feed, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	for _, expected := range []string{
+		`<guid isPermaLink="false">urn:article-api:article:article-1</guid>`,
+		"<pubDate>Thu, 02 Jan 2025 02:04:05 +0000</pubDate>",
+		"<dc:creator>John Doe</dc:creator>",
+		"<category>go</category>",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected feed to contain %s, got\n%s", expected, body)
+		}
+	}
+}
+
+func TestFeedHandler_AuthorAndTagFeeds(t *testing.T) {
+	handler, mockRepo := newFeedTestHandler()
+
+	req := httptest.NewRequest("GET", "http://example.com/authors/author-1/feed.atom", nil)
+	w := httptest.NewRecorder()
+	handler.AuthorFeed(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "<title>Articles by John Doe</title>") {
+		t.Errorf("Expected the author feed, got %d\n%s", w.Code, w.Body.String())
+	}
+	if len(mockRepo.lastParams.AuthorIDs) != 1 || mockRepo.lastParams.AuthorIDs[0] != "author-1" {
+		t.Errorf("Expected the feed to be filtered by author, got %+v", mockRepo.lastParams)
+	}
+
+	req = httptest.NewRequest("GET", "http://example.com/authors/missing/feed.atom", nil)
+	w = httptest.NewRecorder()
+	handler.AuthorFeed(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "http://example.com/tags/Go/feed.atom", nil)
+	w = httptest.NewRecorder()
+	handler.TagFeed(w, req)
+	if w.Code != http.StatusOK || mockRepo.lastParams.Tag != "go" {
+		t.Errorf("Expected the feed to be filtered by the normalized tag, got %d %+v", w.Code, mockRepo.lastParams)
+	}
+}
+
+func TestFeedHandler_ArticlesJSON(t *testing.T) {
+	handler, mockRepo := newFeedTestHandler()
+	mockRepo.articles[0].Body = "First <b>paragraph</b>\n\nSecond line\nThird line"
+
+	req := httptest.NewRequest("GET", "http://example.com/feeds/articles.json", nil)
+	w := httptest.NewRecorder()
+	handler.ArticlesJSON(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/feed+json" {
+		t.Fatalf("Expected a JSON Feed, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	var doc struct {
+		NextURL string `json:"next_url"`
+		Items   []struct {
+			ID            string `json:"id"`
+			ContentText   string `json:"content_text"`
+			ContentHTML   string `json:"content_html"`
+			DatePublished string `json:"date_published"`
+			Authors       []struct {
+				Name string `json:"name"`
+			} `json:"authors"`
+			Tags []string `json:"tags"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("Failed to decode feed: %v", err)
+	}
+	if len(doc.Items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(doc.Items))
+	}
+	item := doc.Items[0]
+	if item.ContentText != mockRepo.articles[0].Body {
+		t.Errorf("Expected the body as content_text, got %q", item.ContentText)
+	}
+	if item.ContentHTML != "<p>First &lt;b&gt;paragraph&lt;/b&gt;</p><p>Second line<br>Third line</p>" {
+		t.Errorf("Unexpected content_html %q", item.ContentHTML)
+	}
+	if item.DatePublished != "2025-01-02T02:04:05Z" || len(item.Authors) != 1 || item.Authors[0].Name != "John Doe" || len(item.Tags) != 1 {
+		t.Errorf("Unexpected item %+v", item)
+	}
+	if !reflect.DeepEqual(mockRepo.lastParams.Fields, jsonFeedFields) {
+		t.Errorf("Expected the body to be fetched, got fields %v", mockRepo.lastParams.Fields)
+	}
+
+	// next_url pages on with a cursor
+	next, err := url.Parse(doc.NextURL)
+	if err != nil || next.Path != "/feeds/articles.json" || next.Query().Get("cursor") == "" {
+		t.Fatalf("Unexpected next_url %q", doc.NextURL)
+	}
+	req = httptest.NewRequest("GET", doc.NextURL, nil)
+	w = httptest.NewRecorder()
+	handler.ArticlesJSON(w, req)
+	if w.Code != http.StatusOK || mockRepo.lastParams.Cursor == nil || mockRepo.lastParams.Cursor.Values[1] != "article-1" {
+		t.Errorf("Expected the next page to continue after article-1, got %d %+v", w.Code, mockRepo.lastParams.Cursor)
+	}
+
+	req = httptest.NewRequest("GET", "http://example.com/feeds/articles.json?cursor=bogus", nil)
+	w = httptest.NewRecorder()
+	handler.ArticlesJSON(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestFeedHandler_ArticlesJSON_Markdown(t *testing.T) {
+	handler, mockRepo := newFeedTestHandler()
+	mockRepo.articles[0].Body = "Some **bold** text"
+	mockRepo.articles[0].ContentFormat = models.ContentFormatMarkdown
+
+	req := httptest.NewRequest("GET", "http://example.com/feeds/articles.json", nil)
+	w := httptest.NewRecorder()
+	handler.ArticlesJSON(w, req)
+
+	var doc struct {
+		Items []struct {
+			ContentText string `json:"content_text"`
+			ContentHTML string `json:"content_html"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil || len(doc.Items) != 1 {
+		t.Fatalf("Failed to decode feed: %v", err)
+	}
+	// content_text carries the text without markup
+	if text := doc.Items[0].ContentText; strings.Contains(text, "**") || !strings.Contains(text, "bold") {
+		t.Errorf("Expected plain text content, got %q", text)
+	}
+	if !strings.Contains(doc.Items[0].ContentHTML, "<strong>bold</strong>") {
+		t.Errorf("Expected rendered HTML content, got %q", doc.Items[0].ContentHTML)
+	}
+}
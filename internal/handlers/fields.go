@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"article-api/internal/models"
+)
+
+// projectListItems reduces list items to the requested fields, nesting dotted
+// fields such as author.name under their parent object. Items are copied into
+// a struct built from the selected fields of ArticleListItem, so every format
+// encodes them with their own types: MessagePack keeps integers and timestamps
+// rather than seeing the floats and strings of a JSON round trip.
+func projectListItems(articles []models.ArticleListItem, fields []string) (interface{}, error) {
+	// Group nested fields under their parent, in the order first requested
+	type selection struct {
+		name     string
+		children []string
+	}
+	var selections []*selection
+	byName := map[string]*selection{}
+	for _, field := range fields {
+		parent, child, nested := strings.Cut(field, ".")
+		s, ok := byName[parent]
+		if !ok {
+			s = &selection{name: parent}
+			byName[parent] = s
+			selections = append(selections, s)
+		}
+		if nested {
+			s.children = append(s.children, child)
+		}
+	}
+
+	itemType := reflect.TypeOf(models.ArticleListItem{})
+	projected := make([]reflect.StructField, len(selections))
+	children := make([][]reflect.StructField, len(selections))
+	for i, s := range selections {
+		field, err := fieldByJSONName(itemType, s.name)
+		if err != nil {
+			return nil, err
+		}
+		fieldType := field.Type
+		if len(s.children) > 0 {
+			for _, child := range s.children {
+				childField, err := fieldByJSONName(fieldType.Elem(), child)
+				if err != nil {
+					return nil, err
+				}
+				children[i] = append(children[i], projectedField(childField, child))
+			}
+			fieldType = reflect.PointerTo(reflect.StructOf(children[i]))
+		}
+		projected[i] = projectedField(reflect.StructField{Name: field.Name, Type: fieldType}, s.name)
+	}
+
+	projectedType := reflect.StructOf(projected)
+	items := reflect.MakeSlice(reflect.SliceOf(projectedType), len(articles), len(articles))
+	for i := range articles {
+		source := reflect.ValueOf(articles[i])
+		item := items.Index(i)
+		for j, field := range projected {
+			value := source.FieldByName(field.Name)
+			if children[j] == nil {
+				item.Field(j).Set(value)
+				continue
+			}
+			if value.IsNil() {
+				continue
+			}
+			object := reflect.New(field.Type.Elem())
+			for k, child := range children[j] {
+				object.Elem().Field(k).Set(value.Elem().FieldByName(child.Name))
+			}
+			item.Field(j).Set(object)
+		}
+	}
+	return items.Interface(), nil
+}
+
+// fieldByJSONName finds the field of a struct type encoded under name
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, error) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tagName, _, _ := strings.Cut(field.Tag.Get("json"), ","); tagName == name {
+			return field, nil
+		}
+	}
+	return reflect.StructField{}, fmt.Errorf("unknown field %q", name)
+}
+
+// projectedField copies a field into a projection under its JSON name, without
+// omitempty: a selected field is always present, even when empty
+func projectedField(field reflect.StructField, name string) reflect.StructField {
+	return reflect.StructField{
+		Name: field.Name,
+		Type: field.Type,
+		Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, name)),
+	}
+}
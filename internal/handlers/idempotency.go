@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"article-api/internal/models"
+	"article-api/internal/repository"
+)
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header
+const maxIdempotencyKeyLength = 255
+
+// Idempotency replays the stored response when a request is retried with the
+// same Idempotency-Key, so retries over flaky networks cannot create an
+// article twice
+type Idempotency struct {
+	store repository.IdempotencyStoreInterface
+}
+
+// NewIdempotency creates the Idempotency-Key middleware
+func NewIdempotency(store repository.IdempotencyStoreInterface) *Idempotency {
+	return &Idempotency{store: store}
+}
+
+// responseCapture passes a response through while keeping a copy of it
+type responseCapture struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (c *responseCapture) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *responseCapture) Write(data []byte) (int, error) {
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+	c.body.Write(data)
+	return c.ResponseWriter.Write(data)
+}
+
+// requestFingerprint identifies a request by its method, URL, negotiated
+// request and response formats and payload. A retry that changes any of them,
+// such as adding ?force=true or asking for XML, is a different request.
+func requestFingerprint(r *http.Request, body []byte) string {
+	sum := sha256.New()
+	fmt.Fprintf(sum, "%s %s?%s\n", r.Method, r.URL.Path, r.URL.RawQuery)
+	fmt.Fprintf(sum, "%s %s\n", requestCodec(r).MediaType(), responseCodec(r).MediaType())
+	sum.Write(body)
+	return fmt.Sprintf("%x", sum.Sum(nil))
+}
+
+// Wrap makes next idempotent. Requests without an Idempotency-Key pass
+// through unchanged. The first response for a key is stored for
+// repository.IdempotencyTTL unless it is a server error, which the client
+// may retry; retries with the same payload get the stored response back and
+// retries with a different payload are rejected with 422. The key is reserved
+// in the store before next runs, so a retry that arrives while the original
+// is still running, on any instance, gets 409.
+func (m *Idempotency) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			writeProblem(w, r, http.StatusBadRequest, fmt.Sprintf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(r, body)
+
+		reserved, err := m.store.ReserveIdempotencyKey(key, fingerprint)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to check Idempotency-Key: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if !reserved {
+			m.replay(w, r, key, fingerprint)
+			return
+		}
+
+		capture := &responseCapture{ResponseWriter: w}
+		next(capture, r)
+		if capture.status == 0 {
+			capture.status = http.StatusOK
+		}
+		if capture.status >= http.StatusInternalServerError {
+			if err := m.store.ReleaseIdempotencyKey(key); err != nil {
+				log.Printf("Failed to release Idempotency-Key %q: %v", key, err)
+			}
+			return
+		}
+
+		err = m.store.SaveIdempotencyRecord(key, models.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      capture.status,
+			Header:      w.Header().Clone(),
+			Body:        capture.body.Bytes(),
+			CreatedAt:   time.Now(),
+		})
+		if err != nil {
+			log.Printf("Failed to store response for Idempotency-Key %q: %v", key, err)
+		}
+	}
+}
+
+// replay answers a request whose key is already reserved with the stored
+// response, or with 409 while the request holding the key is still running
+func (m *Idempotency) replay(w http.ResponseWriter, r *http.Request, key, fingerprint string) {
+	record, err := m.store.GetIdempotencyRecord(key)
+	var notFound *repository.IdempotencyKeyNotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		// The reservation was released or expired since; the client may retry
+		writeProblem(w, r, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Failed to check Idempotency-Key: %v", err), http.StatusInternalServerError)
+	case record.Fingerprint != fingerprint:
+		writeProblem(w, r, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request payload")
+	case record.Status == 0:
+		writeProblem(w, r, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+	default:
+		for name, values := range record.Header {
+			w.Header()[name] = values
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(record.Status)
+		w.Write(record.Body)
+	}
+}
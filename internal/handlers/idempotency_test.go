@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"article-api/internal/cache"
+	"article-api/internal/repository"
+)
+
+func TestIdempotency_ReplaysCreateArticle(t *testing.T) {
+	handler := NewArticleHandler(NewMockArticleRepository())
+	idempotency := NewIdempotency(repository.NewCacheIdempotencyStore(cache.NewMockCacheService()))
+
+	calls := 0
+	create := idempotency.Wrap(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		handler.CreateArticle(w, r)
+	})
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/articles", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		create(w, req)
+		return w
+	}
+
+	payload := `{"author_id": "author-1", "title": "Retry Me", "body": "Body"}`
+	first := post("key-1", payload)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d", http.StatusCreated, first.Code)
+	}
+
+	retry := post("key-1", payload)
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("Expected the stored response, got %d: %s", retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" || retry.Header().Get("Content-Type") != first.Header().Get("Content-Type") {
+		t.Errorf("Unexpected replay headers %v", retry.Header())
+	}
+	if calls != 1 {
+		t.Errorf("Expected the article to be created once, got %d calls", calls)
+	}
+
+	// The same key with another payload is rejected
+	other := post("key-1", `{"author_id": "author-1", "title": "Something Else", "body": "Body"}`)
+	if other.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnprocessableEntity, other.Code)
+	}
+
+	// Requests without a key are not deduplicated
+	post("", payload)
+	post("", payload)
+	if calls != 3 {
+		t.Errorf("Expected requests without a key to run every time, got %d calls", calls)
+	}
+
+	if w := post(strings.Repeat("k", 256), payload); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for an overlong key, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestIdempotency_FingerprintCoversQueryAndFormats(t *testing.T) {
+	idempotency := NewIdempotency(repository.NewCacheIdempotencyStore(cache.NewMockCacheService()))
+	handler := idempotency.Wrap(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	post := func(key, target, accept string) int {
+		req := httptest.NewRequest("POST", target, strings.NewReader(`{"title": "Same"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", accept)
+		req.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w.Code
+	}
+
+	post("key-1", "/articles", "application/json")
+	if code := post("key-1", "/articles?force=true", "application/json"); code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected a retry with another query string to get %d, got %d", http.StatusUnprocessableEntity, code)
+	}
+
+	post("key-2", "/articles", "application/json")
+	if code := post("key-2", "/articles", "application/xml"); code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected a retry asking for another format to get %d, got %d", http.StatusUnprocessableEntity, code)
+	}
+}
+
+func TestIdempotency_ServerErrorsAreNotStored(t *testing.T) {
+	idempotency := NewIdempotency(repository.NewCacheIdempotencyStore(cache.NewMockCacheService()))
+
+	status := http.StatusInternalServerError
+	handler := idempotency.Wrap(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	})
+
+	for _, expected := range []int{http.StatusInternalServerError, http.StatusCreated, http.StatusCreated} {
+		req := httptest.NewRequest("POST", "/articles", strings.NewReader("{}"))
+		req.Header.Set("Idempotency-Key", "key-1")
+		w := httptest.NewRecorder()
+		handler(w, req)
+		if w.Code != expected {
+			t.Errorf("Expected status code %d, got %d", expected, w.Code)
+		}
+		status = http.StatusCreated
+	}
+}
+
+func TestIdempotency_ConcurrentRetry(t *testing.T) {
+	store := repository.NewCacheIdempotencyStore(cache.NewMockCacheService())
+	idempotency := NewIdempotency(store)
+	if reserved, _ := store.ReserveIdempotencyKey("key-1", requestFingerprint(httptest.NewRequest("POST", "/articles", nil), []byte("{}"))); !reserved {
+		t.Fatal("Expected to reserve the key")
+	}
+
+	req := httptest.NewRequest("POST", "/articles", strings.NewReader("{}"))
+	req.Header.Set("Idempotency-Key", "key-1")
+	w := httptest.NewRecorder()
+	idempotency.Wrap(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected the retry not to run while the original is in flight")
+	})(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, w.Code)
+	}
+}
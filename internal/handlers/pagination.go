@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// paginatedProfile is the Accept profile that selects the list envelope, e.g.
+// Accept: application/json; profile="urn:article-api:paginated"
+const paginatedProfile = "urn:article-api:paginated"
+
+// listEnvelope wraps a page of results for clients that cannot read headers
+type listEnvelope struct {
+	Data  interface{} `json:"data"`
+	Meta  listMeta    `json:"meta"`
+	Links listLinks   `json:"links"`
+}
+
+// listMeta describes the page within the whole listing
+type listMeta struct {
+	Total      int    `json:"total"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// listLinks holds navigation links relative to the listing endpoint
+type listLinks struct {
+	Self  string `json:"self"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	First string `json:"first"`
+	Last  string `json:"last"`
+}
+
+// wantsEnvelope reports whether the client opted into the list envelope,
+// through ?envelope=true or the paginated Accept profile
+func wantsEnvelope(r *http.Request) bool {
+	if r.URL.Query().Get("envelope") == "true" {
+		return true
+	}
+	for _, mediaRange := range strings.Split(r.Header.Get("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		for _, profile := range strings.Fields(params["profile"]) {
+			if profile == paginatedProfile {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// totalPages returns the number of pages of limit items, at least one
+func totalPages(total, limit int) int {
+	if pages := (total + limit - 1) / limit; pages > 1 {
+		return pages
+	}
+	return 1
+}
+
+// paginationLinks builds navigation links for a listing request. Cursor
+// requests page by cursor, offset requests page by page number; first and last
+// always address pages so clients can jump to either end.
+func paginationLinks(r *http.Request, meta listMeta) listLinks {
+	query := r.URL.Query()
+	cursorMode := query.Get("cursor") != ""
+
+	link := func(change func(q url.Values)) string {
+		q := url.Values{}
+		for key, values := range query {
+			q[key] = values
+		}
+		change(q)
+		return r.URL.Path + "?" + q.Encode()
+	}
+	toPage := func(page int) string {
+		return link(func(q url.Values) {
+			q.Del("cursor")
+			q.Set("page", strconv.Itoa(page))
+		})
+	}
+	toCursor := func(token string) string {
+		return link(func(q url.Values) {
+			q.Del("page")
+			q.Set("cursor", token)
+		})
+	}
+
+	links := listLinks{
+		Self:  r.URL.RequestURI(),
+		First: toPage(1),
+		Last:  toPage(meta.TotalPages),
+	}
+	switch {
+	case cursorMode:
+		if meta.NextCursor != "" {
+			links.Next = toCursor(meta.NextCursor)
+		}
+		if meta.PrevCursor != "" {
+			links.Prev = toCursor(meta.PrevCursor)
+		}
+	default:
+		if meta.Page < meta.TotalPages {
+			links.Next = toPage(meta.Page + 1)
+		}
+		if meta.Page > 1 {
+			links.Prev = toPage(meta.Page - 1)
+		}
+	}
+	return links
+}
+
+// setLinkHeader emits the navigation links as an RFC 8288 Link header
+func setLinkHeader(w http.ResponseWriter, links listLinks) {
+	var values []string
+	for _, link := range []struct{ rel, target string }{
+		{"self", links.Self},
+		{"first", links.First},
+		{"prev", links.Prev},
+		{"next", links.Next},
+		{"last", links.Last},
+	} {
+		if link.target != "" {
+			values = append(values, "<"+link.target+`>; rel="`+link.rel+`"`)
+		}
+	}
+	w.Header().Set("Link", strings.Join(values, ", "))
+}
@@ -0,0 +1,12 @@
+package handlers
+
+import "strings"
+
+// PathSegments splits the part of a URL path after prefix into its non-empty segments
+func PathSegments(path, prefix string) []string {
+	trimmed := strings.Trim(strings.TrimPrefix(path, prefix), "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"article-api/internal/codec"
+	"article-api/internal/models"
+)
+
+// Problem represents an RFC 7807 problem details response
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// InvalidParams lists every rejected query parameter, as in RFC 7807 section 3
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+	// DuplicateOf points at the existing article a new one duplicates
+	DuplicateOf *models.DuplicateArticle `json:"duplicate_of,omitempty"`
+}
+
+// XMLRoot names the root element of XML problems, as in RFC 7807 appendix A
+func (Problem) XMLRoot() xml.Name {
+	return xml.Name{Space: "urn:ietf:rfc:7807", Local: "problem"}
+}
+
+// InvalidParam describes why a single request parameter was rejected
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// writeProblem writes a problem details error response
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	writeProblemDetails(w, r, Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
+
+// writeInvalidParams writes a 400 problem response listing each invalid parameter
+func writeInvalidParams(w http.ResponseWriter, r *http.Request, invalid []InvalidParam) {
+	writeProblemDetails(w, r, Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(http.StatusBadRequest),
+		Status:        http.StatusBadRequest,
+		Detail:        "One or more query parameters are invalid",
+		Instance:      r.URL.Path,
+		InvalidParams: invalid,
+	})
+}
+
+// writeProblemDetails writes a problem in the format negotiated for the
+// request, as application/problem+json or application/problem+xml for JSON
+// and XML
+func writeProblemDetails(w http.ResponseWriter, r *http.Request, problem Problem) {
+	c := responseCodec(r)
+	contentType := c.MediaType()
+	switch c {
+	case codec.JSON:
+		contentType = "application/problem+json"
+	case codec.XML:
+		contentType = "application/problem+xml"
+	}
+	writeCodec(w, c, contentType, problem.Status, problem)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"article-api/internal/repository"
+)
+
+// defaultRelatedLimit is the number of related articles returned without ?limit=
+const defaultRelatedLimit = 5
+
+// RelatedArticles handles GET /articles/{id}/related, the published articles
+// most similar to an article by shared tags, author and title
+func (h *ArticleHandler) RelatedArticles(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) == 0 {
+		writeProblem(w, r, http.StatusNotFound, "Article ID is required")
+		return
+	}
+
+	limit := defaultRelatedLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > repository.MaxRelatedLimit {
+			writeInvalidParams(w, r, []InvalidParam{{
+				Name:   "limit",
+				Reason: fmt.Sprintf("must be an integer between 1 and %d", repository.MaxRelatedLimit),
+			}})
+			return
+		}
+		limit = parsed
+	}
+
+	article, err := h.repo.GetArticleByID(segments[0])
+	if err != nil {
+		h.writeArticleError(w, r, segments[0], err)
+		return
+	}
+
+	if !h.canView(r, article) {
+		writeProblem(w, r, http.StatusNotFound, fmt.Sprintf("Article %s not found", segments[0]))
+		return
+	}
+
+	related, err := h.repo.RelatedArticles(article.ID, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to find related articles: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := writeEncoded(w, r, http.StatusOK, related); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
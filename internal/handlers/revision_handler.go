@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"article-api/internal/diff"
+	"article-api/internal/models"
+	"article-api/internal/repository"
+)
+
+// ListRevisions handles GET /articles/{id}/revisions
+func (h *ArticleHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) == 0 {
+		writeProblem(w, r, http.StatusNotFound, "Article ID is required")
+		return
+	}
+
+	if !h.checkRevisionsVisible(w, r, segments[0]) {
+		return
+	}
+
+	revisions, err := h.repo.ListRevisions(segments[0])
+	if err != nil {
+		h.writeRevisionError(w, r, segments[0], err)
+		return
+	}
+
+	if err := writeEncoded(w, r, http.StatusOK, revisions); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetRevision handles GET /articles/{id}/revisions/{rev}
+func (h *ArticleHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) != 3 {
+		writeProblem(w, r, http.StatusNotFound, "Revision number is required")
+		return
+	}
+
+	revision, err := strconv.Atoi(segments[2])
+	if err != nil || revision <= 0 {
+		writeProblem(w, r, http.StatusBadRequest, "Revision must be a positive integer")
+		return
+	}
+
+	if !h.checkRevisionsVisible(w, r, segments[0]) {
+		return
+	}
+
+	rev, err := h.repo.GetRevision(segments[0], revision)
+	if err != nil {
+		h.writeRevisionError(w, r, segments[0], err)
+		return
+	}
+
+	if err := writeEncoded(w, r, http.StatusOK, rev); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DiffRevisions handles GET /articles/{id}/revisions/diff?from=1&to=2&mode=line|word
+func (h *ArticleHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/articles/")
+	if len(segments) == 0 {
+		writeProblem(w, r, http.StatusNotFound, "Article ID is required")
+		return
+	}
+
+	query := r.URL.Query()
+	from, err := strconv.Atoi(query.Get("from"))
+	if err != nil || from <= 0 {
+		writeProblem(w, r, http.StatusBadRequest, "from must be a positive revision number")
+		return
+	}
+	to, err := strconv.Atoi(query.Get("to"))
+	if err != nil || to <= 0 {
+		writeProblem(w, r, http.StatusBadRequest, "to must be a positive revision number")
+		return
+	}
+
+	mode := query.Get("mode")
+	if mode == "" {
+		mode = "line"
+	}
+	var differ func(a, b string) []diff.Op
+	switch mode {
+	case "line":
+		differ = diff.Lines
+	case "word":
+		differ = diff.Words
+	default:
+		writeProblem(w, r, http.StatusBadRequest, "mode must be one of line, word")
+		return
+	}
+
+	if !h.checkRevisionsVisible(w, r, segments[0]) {
+		return
+	}
+
+	fromRev, err := h.repo.GetRevision(segments[0], from)
+	if err != nil {
+		h.writeRevisionError(w, r, segments[0], err)
+		return
+	}
+	toRev, err := h.repo.GetRevision(segments[0], to)
+	if err != nil {
+		h.writeRevisionError(w, r, segments[0], err)
+		return
+	}
+
+	result := models.RevisionDiff{
+		ArticleID: segments[0],
+		From:      from,
+		To:        to,
+		Mode:      mode,
+		Title:     differ(fromRev.Title, toRev.Title),
+		Body:      differ(fromRev.Body, toRev.Body),
+	}
+
+	if err := writeEncoded(w, r, http.StatusOK, result); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// checkRevisionsVisible reports whether the request may see the history of an
+// article, which reveals its content like the article itself. Otherwise it
+// writes a 404 and returns false.
+func (h *ArticleHandler) checkRevisionsVisible(w http.ResponseWriter, r *http.Request, id string) bool {
+	article, err := h.repo.GetArticleByID(id)
+	if err != nil {
+		h.writeArticleError(w, r, id, err)
+		return false
+	}
+	if !h.canView(r, article) {
+		writeProblem(w, r, http.StatusNotFound, fmt.Sprintf("Article %s not found", id))
+		return false
+	}
+	return true
+}
+
+// writeRevisionError maps repository errors for revisions to problem responses
+func (h *ArticleHandler) writeRevisionError(w http.ResponseWriter, r *http.Request, id string, err error) {
+	var notFound *repository.RevisionNotFoundError
+	if errors.As(err, &notFound) {
+		writeProblem(w, r, http.StatusNotFound, fmt.Sprintf("Revision %d of article %s not found", notFound.Revision, id))
+		return
+	}
+	h.writeArticleError(w, r, id, err)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"article-api/internal/cache"
+	"article-api/internal/config"
+	"article-api/internal/repository"
+	"article-api/internal/sitemap"
+)
+
+// sitemapContentType is the media type of sitemaps and sitemap indexes
+const sitemapContentType = "application/xml; charset=utf-8"
+
+// SitemapHandler serves sitemaps of the published articles
+type SitemapHandler struct {
+	repo      repository.SitemapRepositoryInterface
+	cache     cache.CacheServiceInterface
+	cacheTTL  int
+	publicURL string
+	// pageSize is the number of URLs per sitemap, sitemap.MaxURLs outside tests
+	pageSize int
+}
+
+// NewSitemapHandler creates a new sitemap handler
+func NewSitemapHandler(repo repository.SitemapRepositoryInterface, cacheService cache.CacheServiceInterface) *SitemapHandler {
+	cfg := config.LoadConfig()
+
+	return &SitemapHandler{
+		repo:      repo,
+		cache:     cacheService,
+		cacheTTL:  cfg.Redis.ListTTL,
+		publicURL: publicBaseURL(cfg.App.BaseURL),
+		pageSize:  sitemap.MaxURLs,
+	}
+}
+
+// Sitemap handles GET /sitemap.xml. Up to one sitemap's worth of articles are
+// listed directly; beyond that it becomes a sitemap index pointing at
+// /sitemaps/articles-{n}.xml.
+func (h *SitemapHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	if !requireBaseURL(w, r, h.publicURL) {
+		return
+	}
+	base := h.publicURL
+	key := repository.SitemapCacheKey("index")
+
+	doc, err := renderCached(h.cache, key, h.cacheTTL, func() ([]byte, time.Time, error) {
+		pages, err := h.sitemapPages()
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if len(pages) <= 1 {
+			return h.renderPage(base, 1)
+		}
+
+		sitemaps := make([]sitemap.Sitemap, len(pages))
+		var modified time.Time
+		for i, lastMod := range pages {
+			sitemaps[i] = sitemap.Sitemap{Loc: fmt.Sprintf("%s/sitemaps/articles-%d.xml", base, i+1), LastMod: lastMod}
+			if lastMod.After(modified) {
+				modified = lastMod
+			}
+		}
+		body, err := sitemap.Index(sitemaps)
+		return body, modified, err
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to build sitemap: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeCachedDocument(w, r, doc, sitemapContentType, h.cacheTTL)
+}
+
+// ArticlesSitemap handles GET /sitemaps/articles-{n}.xml, one page of the
+// sitemap index
+func (h *SitemapHandler) ArticlesSitemap(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/sitemaps/")
+	number := strings.TrimSuffix(strings.TrimPrefix(name, "articles-"), ".xml")
+	page, err := strconv.Atoi(number)
+	if err != nil || page < 1 || name != "articles-"+strconv.Itoa(page)+".xml" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !requireBaseURL(w, r, h.publicURL) {
+		return
+	}
+
+	// Only pages the index lists exist, so arbitrary page numbers are neither
+	// queried nor cached
+	pages, err := h.sitemapPages()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to build sitemap: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if page > len(pages) && page > 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	base := h.publicURL
+	key := repository.SitemapCacheKey(fmt.Sprintf("page:%d", page))
+
+	doc, err := renderCached(h.cache, key, h.cacheTTL, func() ([]byte, time.Time, error) {
+		return h.renderPage(base, page)
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to build sitemap: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if doc.Body == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeCachedDocument(w, r, doc, sitemapContentType, h.cacheTTL)
+}
+
+// sitemapPages returns the latest modification time of each sitemap page,
+// reading through the cache like the sitemaps themselves
+func (h *SitemapHandler) sitemapPages() ([]time.Time, error) {
+	key := repository.SitemapCacheKey("pages")
+
+	var pages []time.Time
+	if err := h.cache.Get(key, &pages); err == nil {
+		return pages, nil
+	}
+
+	pages, err := h.repo.SitemapPages(h.pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if cacheErr := h.cache.SetWithTTL(key, pages, h.cacheTTL); cacheErr != nil {
+		log.Printf("Failed to cache %s: %v", key, cacheErr)
+	}
+	return pages, nil
+}
+
+// renderPage renders one page of article URLs. Pages past the end render as
+// nil, except the first, which is an empty sitemap while there are no articles.
+func (h *SitemapHandler) renderPage(base string, page int) ([]byte, time.Time, error) {
+	entries, err := h.repo.SitemapEntries(page, h.pageSize)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(entries) == 0 && page > 1 {
+		return nil, time.Time{}, nil
+	}
+
+	urls := make([]sitemap.URL, len(entries))
+	var modified time.Time
+	for i, entry := range entries {
+		urls[i] = sitemap.URL{Loc: base + "/articles/by-slug/" + url.PathEscape(entry.Slug), LastMod: entry.LastMod}
+		if entry.LastMod.After(modified) {
+			modified = entry.LastMod
+		}
+	}
+	body, err := sitemap.URLSet(urls)
+	return body, modified, err
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"article-api/internal/cache"
+	"article-api/internal/models"
+	"article-api/internal/repository"
+)
+
+// MockSitemapRepository pages over a fixed list of sitemap entries
+type MockSitemapRepository struct {
+	entries []models.SitemapEntry
+	queries int
+}
+
+func (m *MockSitemapRepository) SitemapPages(size int) ([]time.Time, error) {
+	m.queries++
+	var pages []time.Time
+	for i, entry := range m.entries {
+		if i%size == 0 {
+			pages = append(pages, entry.LastMod)
+		}
+		if entry.LastMod.After(pages[len(pages)-1]) {
+			pages[len(pages)-1] = entry.LastMod
+		}
+	}
+	return pages, nil
+}
+
+func (m *MockSitemapRepository) SitemapEntries(page, size int) ([]models.SitemapEntry, error) {
+	m.queries++
+	start := (page - 1) * size
+	if start >= len(m.entries) {
+		return nil, nil
+	}
+	end := start + size
+	if end > len(m.entries) {
+		end = len(m.entries)
+	}
+	return m.entries[start:end], nil
+}
+
+func newSitemapTestHandler(count, pageSize int) (*SitemapHandler, *MockSitemapRepository) {
+	mockRepo := &MockSitemapRepository{}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		mockRepo.entries = append(mockRepo.entries, models.SitemapEntry{
+			Slug:    "article-" + string(rune('a'+i)),
+			LastMod: start.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	handler := NewSitemapHandler(mockRepo, cache.NewMockCacheService())
+	handler.publicURL = "https://api.example.com"
+	handler.pageSize = pageSize
+	return handler, mockRepo
+}
+
+func TestSitemapHandler_Sitemap(t *testing.T) {
+	handler, mockRepo := newSitemapTestHandler(2, 10)
+
+	req := httptest.NewRequest("GET", "/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	handler.Sitemap(w, req)
+
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/xml") {
+		t.Fatalf("Expected a sitemap, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "<urlset") || strings.Count(body, "<url>") != 2 {
+		t.Errorf("Expected a sitemap with 2 URLs, got\n%s", body)
+	}
+	if !strings.Contains(body, "<loc>https://api.example.com/articles/by-slug/article-b</loc>") ||
+		!strings.Contains(body, "<lastmod>2025-01-01T01:00:00Z</lastmod>") {
+		t.Errorf("Expected URLs from the configured base URL with lastmod, got\n%s", body)
+	}
+	if w.Header().Get("Last-Modified") != "Wed, 01 Jan 2025 01:00:00 GMT" {
+		t.Errorf("Unexpected Last-Modified %q", w.Header().Get("Last-Modified"))
+	}
+
+	// The rendered sitemap is cached
+	queries := mockRepo.queries
+	req = httptest.NewRequest("GET", "/sitemap.xml", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	handler.Sitemap(w, req)
+	if w.Code != http.StatusNotModified || mockRepo.queries != queries {
+		t.Errorf("Expected a cached 304, got %d after %d queries", w.Code, mockRepo.queries-queries)
+	}
+}
+
+func TestSitemapHandler_SitemapIndex(t *testing.T) {
+	handler, _ := newSitemapTestHandler(5, 2)
+
+	req := httptest.NewRequest("GET", "/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	handler.Sitemap(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, "<sitemapindex") || strings.Count(body, "<sitemap>") != 3 {
+		t.Fatalf("Expected an index of 3 sitemaps, got\n%s", body)
+	}
+	if !strings.Contains(body, "<loc>https://api.example.com/sitemaps/articles-3.xml</loc>") ||
+		!strings.Contains(body, "<lastmod>2025-01-01T03:00:00Z</lastmod>") {
+		t.Errorf("Unexpected sitemap index\n%s", body)
+	}
+
+	req = httptest.NewRequest("GET", "/sitemaps/articles-3.xml", nil)
+	w = httptest.NewRecorder()
+	handler.ArticlesSitemap(w, req)
+	if w.Code != http.StatusOK || strings.Count(w.Body.String(), "<url>") != 1 || !strings.Contains(w.Body.String(), "article-e") {
+		t.Errorf("Expected the last page with 1 URL, got %d\n%s", w.Code, w.Body.String())
+	}
+
+	for _, path := range []string{"/sitemaps/articles-4.xml", "/sitemaps/articles-0.xml", "/sitemaps/articles-01.xml", "/sitemaps/other.xml"} {
+		req = httptest.NewRequest("GET", path, nil)
+		w = httptest.NewRecorder()
+		handler.ArticlesSitemap(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusNotFound, path, w.Code)
+		}
+	}
+}
+
+func TestSitemapHandler_PagesPastTheEnd(t *testing.T) {
+	handler, mockRepo := newSitemapTestHandler(5, 2)
+
+	// Pages past the last one the index lists are rejected before any
+	// sitemap is rendered or cached
+	for _, path := range []string{"/sitemaps/articles-4.xml", "/sitemaps/articles-9223372036854775807.xml"} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		handler.ArticlesSitemap(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusNotFound, path, w.Code)
+		}
+	}
+	var doc cachedDocument
+	if err := handler.cache.Get(repository.SitemapCacheKey("page:4"), &doc); err == nil {
+		t.Error("Expected no sitemap to be cached for a page past the end")
+	}
+
+	// The page count is cached along with the sitemaps
+	queries := mockRepo.queries
+	req := httptest.NewRequest("GET", "/sitemaps/articles-5.xml", nil)
+	handler.ArticlesSitemap(httptest.NewRecorder(), req)
+	if mockRepo.queries != queries {
+		t.Errorf("Expected the cached page count to be used, got %d queries", mockRepo.queries-queries)
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"article-api/internal/config"
+	"article-api/internal/models"
+	"article-api/internal/repository"
+)
+
+// TagHandler handles HTTP requests for tags
+type TagHandler struct {
+	repo        repository.TagRepositoryInterface
+	adminAPIKey string
+}
+
+// NewTagHandler creates a new tag handler
+func NewTagHandler(repo repository.TagRepositoryInterface) *TagHandler {
+	cfg := config.LoadConfig()
+
+	return &TagHandler{
+		repo:        repo,
+		adminAPIKey: cfg.App.APIKey,
+	}
+}
+
+// isAdmin reports whether the request carries the configured admin API key
+func (h *TagHandler) isAdmin(r *http.Request) bool {
+	return h.adminAPIKey != "" && r.Header.Get("X-API-Key") == h.adminAPIKey
+}
+
+// ListTags handles GET /tags, returning usage counts for a tag cloud
+func (h *TagHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	limit := parseIntParam(r.URL.Query().Get("limit"), 0)
+
+	tags, err := h.repo.ListTags(limit)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list tags: %v", err))
+		return
+	}
+
+	if err := writeEncoded(w, r, http.StatusOK, tags); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RenameTag handles PATCH /tags/{name}
+func (h *TagHandler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	segments := PathSegments(r.URL.Path, "/tags/")
+	if len(segments) != 1 {
+		writeProblem(w, r, http.StatusNotFound, "Tag name is required")
+		return
+	}
+
+	if !h.isAdmin(r) {
+		writeProblem(w, r, http.StatusForbidden, "Renaming tags requires a valid X-API-Key")
+		return
+	}
+
+	var req models.RenameTagRequest
+	if err := decodeRequest(r, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, invalidPayload(r))
+		return
+	}
+
+	newName := models.NormalizeTag(req.Name)
+	if newName == "" || len(newName) > models.MaxTagLength {
+		writeProblem(w, r, http.StatusBadRequest, fmt.Sprintf("Tag name must be between 1 and %d characters", models.MaxTagLength))
+		return
+	}
+
+	tag, err := h.repo.RenameTag(models.NormalizeTag(segments[0]), newName)
+	if err != nil {
+		var exists *repository.TagExistsError
+		if errors.As(err, &exists) {
+			writeProblem(w, r, http.StatusConflict, fmt.Sprintf("Tag %s already exists; merge the tags instead", exists.Name))
+			return
+		}
+		h.writeTagError(w, r, err)
+		return
+	}
+
+	if err := writeEncoded(w, r, http.StatusOK, tag); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// MergeTags handles POST /tags/merge
+func (h *TagHandler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	if !h.isAdmin(r) {
+		writeProblem(w, r, http.StatusForbidden, "Merging tags requires a valid X-API-Key")
+		return
+	}
+
+	var req models.MergeTagsRequest
+	if err := decodeRequest(r, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, invalidPayload(r))
+		return
+	}
+
+	target := models.NormalizeTag(req.Target)
+	sources := normalizeTagParams(req.Sources)
+	if target == "" || len(target) > models.MaxTagLength || len(sources) == 0 {
+		writeProblem(w, r, http.StatusBadRequest, "Missing required fields: sources, target")
+		return
+	}
+
+	tag, err := h.repo.MergeTags(sources, target)
+	if err != nil {
+		h.writeTagError(w, r, err)
+		return
+	}
+
+	if err := writeEncoded(w, r, http.StatusOK, tag); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeTagError maps repository errors for tags to problem responses
+func (h *TagHandler) writeTagError(w http.ResponseWriter, r *http.Request, err error) {
+	var notFound *repository.TagNotFoundError
+	if errors.As(err, &notFound) {
+		writeProblem(w, r, http.StatusNotFound, fmt.Sprintf("Tag %s not found", notFound.Name))
+		return
+	}
+	writeProblem(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to process tags: %v", err))
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"article-api/internal/models"
+	"article-api/internal/repository"
+)
+
+// MockTagRepository is a mock implementation of TagRepository for testing
+type MockTagRepository struct {
+	counts map[string]int
+}
+
+func NewMockTagRepository() *MockTagRepository {
+	return &MockTagRepository{
+		counts: map[string]int{"go": 3, "golang": 1, "databases": 2},
+	}
+}
+
+func (m *MockTagRepository) ListTags(limit int) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	for name, count := range m.counts {
+		tags = append(tags, models.Tag{Name: name, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Count > tags[j].Count })
+	if limit > 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags, nil
+}
+
+func (m *MockTagRepository) RenameTag(name, newName string) (*models.Tag, error) {
+	count, exists := m.counts[name]
+	if !exists {
+		return nil, &repository.TagNotFoundError{Name: name}
+	}
+	if _, taken := m.counts[newName]; taken {
+		return nil, &repository.TagExistsError{Name: newName}
+	}
+	delete(m.counts, name)
+	m.counts[newName] = count
+	return &models.Tag{Name: newName, Count: count}, nil
+}
+
+func (m *MockTagRepository) MergeTags(sources []string, target string) (*models.Tag, error) {
+	for _, source := range sources {
+		count, exists := m.counts[source]
+		if !exists {
+			return nil, &repository.TagNotFoundError{Name: source}
+		}
+		m.counts[target] += count
+		delete(m.counts, source)
+	}
+	return &models.Tag{Name: target, Count: m.counts[target]}, nil
+}
+
+func TestTagHandler_ListTags(t *testing.T) {
+	handler := NewTagHandler(NewMockTagRepository())
+
+	req := httptest.NewRequest("GET", "/tags?limit=2", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTags(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var tags []models.Tag
+	if err := json.NewDecoder(w.Body).Decode(&tags); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(tags) != 2 || tags[0].Name != "go" {
+		t.Errorf("Expected the 2 most used tags, got %+v", tags)
+	}
+}
+
+func TestTagHandler_RenameTag(t *testing.T) {
+	handler := NewTagHandler(NewMockTagRepository())
+	handler.adminAPIKey = "admin-key"
+
+	req := httptest.NewRequest("PATCH", "/tags/databases", bytes.NewBufferString(`{"name":"  Data Bases "}`))
+	w := httptest.NewRecorder()
+	handler.RenameTag(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	req = httptest.NewRequest("PATCH", "/tags/databases", bytes.NewBufferString(`{"name":"  Data Bases "}`))
+	req.Header.Set("X-API-Key", "admin-key")
+	w = httptest.NewRecorder()
+	handler.RenameTag(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var tag models.Tag
+	if err := json.NewDecoder(w.Body).Decode(&tag); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if tag.Name != "data bases" {
+		t.Errorf("Expected normalized name 'data bases', got '%s'", tag.Name)
+	}
+
+	// Renaming onto an existing tag must go through merge
+	req = httptest.NewRequest("PATCH", "/tags/golang", bytes.NewBufferString(`{"name":"go"}`))
+	req.Header.Set("X-API-Key", "admin-key")
+	w = httptest.NewRecorder()
+	handler.RenameTag(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestTagHandler_MergeTags(t *testing.T) {
+	handler := NewTagHandler(NewMockTagRepository())
+	handler.adminAPIKey = "admin-key"
+
+	req := httptest.NewRequest("POST", "/tags/merge", bytes.NewBufferString(`{"sources":["golang"],"target":"go"}`))
+	req.Header.Set("X-API-Key", "admin-key")
+	w := httptest.NewRecorder()
+
+	handler.MergeTags(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var tag models.Tag
+	if err := json.NewDecoder(w.Body).Decode(&tag); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if tag.Name != "go" || tag.Count != 4 {
+		t.Errorf("Expected merged tag go with 4 articles, got %+v", tag)
+	}
+}
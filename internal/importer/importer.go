@@ -0,0 +1,317 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"article-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// Store persists imported articles; *repository.ArticleRepository satisfies it
+type Store interface {
+	GetAuthorsByIDs(ids []string) (map[string]*models.Author, error)
+	ImportArticles(reqs []models.CreateArticleRequest) ([]string, error)
+}
+
+// Options configures an import run
+type Options struct {
+	Format    string
+	BatchSize int
+	// CheckpointPath records how many input records are done after every batch;
+	// a later run with the same path skips them. Empty disables checkpoints.
+	CheckpointPath string
+	// Rejects receives one JSON line per rejected record, in record order. Lines
+	// are written along with the checkpoint, so a resumed run continues a
+	// rejects output cut back to the checkpoint's RejectsSize.
+	Rejects io.Writer
+	// OnProgress is called after every batch
+	OnProgress func(Progress)
+}
+
+// Progress counts the input records handled so far, including records that
+// were already done when the run resumed from a checkpoint
+type Progress struct {
+	Records  int `json:"records"`
+	Imported int `json:"imported"`
+	Rejected int `json:"rejected"`
+	// RejectsSize is the number of bytes written to Options.Rejects
+	RejectsSize int64 `json:"rejects_size"`
+}
+
+// rejection is a line of the rejected-rows file
+type rejection struct {
+	Record int    `json:"record"`
+	Error  string `json:"error"`
+	Raw    string `json:"raw"`
+}
+
+// pending is a validated record waiting for its batch to be inserted
+type pending struct {
+	number int
+	raw    string
+	req    models.CreateArticleRequest
+}
+
+// importer holds the state of one import run
+type importer struct {
+	store    Store
+	opts     Options
+	progress Progress
+	authors  map[string]bool
+	batch    []pending
+	// rejected holds the rejections not written yet; see writeRejects
+	rejected []rejection
+	// read counts the input records consumed, including skipped ones
+	read int
+}
+
+// Run streams records from r into the store in batches. Invalid records are
+// written to opts.Rejects instead of stopping the import. When the context is
+// cancelled the current batch is finished, checkpointed and ctx.Err() returned.
+func Run(ctx context.Context, r io.Reader, store Store, opts Options) (Progress, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.Rejects == nil {
+		opts.Rejects = io.Discard
+	}
+
+	reader, err := newRecordReader(r, opts.Format)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	imp := &importer{store: store, opts: opts, authors: map[string]bool{}}
+	if imp.progress, err = LoadCheckpoint(opts.CheckpointPath); err != nil {
+		return Progress{}, err
+	}
+
+	// Skip the records a previous run already handled
+	for skipped := 0; skipped < imp.progress.Records; skipped++ {
+		if _, err := reader.next(); err == io.EOF {
+			return imp.progress, nil
+		} else if err != nil {
+			return imp.progress, fmt.Errorf("failed to skip to checkpoint: %w", err)
+		}
+	}
+
+	imp.read = imp.progress.Records
+	for {
+		if err := ctx.Err(); err != nil {
+			if flushErr := imp.flush(); flushErr != nil {
+				return imp.progress, flushErr
+			}
+			return imp.progress, err
+		}
+
+		rec, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return imp.progress, fmt.Errorf("failed to read record %d: %w", imp.read+1, err)
+		}
+		imp.read++
+		number := imp.read
+
+		if rec.err == nil {
+			rec.err = rec.req.Validate()
+		}
+		if rec.err != nil {
+			imp.reject(number, rec.raw, rec.err)
+		} else {
+			imp.batch = append(imp.batch, pending{number: number, raw: rec.raw, req: rec.req})
+		}
+		if len(imp.batch) >= opts.BatchSize || len(imp.rejected) >= opts.BatchSize {
+			if err := imp.flush(); err != nil {
+				return imp.progress, err
+			}
+		}
+	}
+
+	if err := imp.flush(); err != nil {
+		return imp.progress, err
+	}
+	return imp.progress, nil
+}
+
+// flush inserts the pending batch and checkpoints the progress. Rows with
+// unknown authors are rejected up front; if the batch insert still fails on
+// bad data, its rows are retried one by one so a single bad row cannot sink
+// the batch. Other failures, such as a lost connection, stop the import
+// without checkpointing the rows that were not inserted.
+func (imp *importer) flush() error {
+	if len(imp.batch) == 0 {
+		imp.progress.Records = imp.read
+		return imp.checkpoint()
+	}
+	batch := imp.batch
+	imp.batch = nil
+
+	if err := imp.resolveAuthors(batch); err != nil {
+		return err
+	}
+
+	var rows []pending
+	for _, row := range batch {
+		if !imp.authors[row.req.AuthorID] {
+			imp.reject(row.number, row.raw, errors.New("author not found"))
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if err := imp.insert(rows); err != nil {
+		if !isDataError(err) {
+			return err
+		}
+		for _, row := range rows {
+			if err := imp.insert([]pending{row}); err != nil {
+				if !isDataError(err) {
+					// Keep the rows inserted so far; a resumed run starts at this one
+					imp.progress.Records = row.number - 1
+					if cpErr := imp.checkpoint(); cpErr != nil {
+						return cpErr
+					}
+					return err
+				}
+				imp.reject(row.number, row.raw, err)
+			}
+		}
+	}
+
+	imp.progress.Records = imp.read
+	if err := imp.checkpoint(); err != nil {
+		return err
+	}
+	if imp.opts.OnProgress != nil {
+		imp.opts.OnProgress(imp.progress)
+	}
+	return nil
+}
+
+// isDataError reports whether an insert was refused because of the rows
+// themselves: PostgreSQL data exceptions (class 22) and integrity constraint
+// violations (class 23)
+func isDataError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	class := pqErr.Code.Class()
+	return class == "22" || class == "23"
+}
+
+// insert imports rows as one batch
+func (imp *importer) insert(rows []pending) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	reqs := make([]models.CreateArticleRequest, len(rows))
+	for i, row := range rows {
+		reqs[i] = row.req
+	}
+	if _, err := imp.store.ImportArticles(reqs); err != nil {
+		return err
+	}
+	imp.progress.Imported += len(rows)
+	return nil
+}
+
+// resolveAuthors looks up the authors of a batch that have not been seen yet
+func (imp *importer) resolveAuthors(batch []pending) error {
+	var unknown []string
+	for _, row := range batch {
+		if _, seen := imp.authors[row.req.AuthorID]; !seen {
+			imp.authors[row.req.AuthorID] = false
+			unknown = append(unknown, row.req.AuthorID)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	found, err := imp.store.GetAuthorsByIDs(unknown)
+	if err != nil {
+		for _, id := range unknown {
+			delete(imp.authors, id)
+		}
+		return err
+	}
+	for id := range found {
+		imp.authors[id] = true
+	}
+	return nil
+}
+
+// reject queues a record for the rejected-rows output
+func (imp *importer) reject(number int, raw string, reason error) {
+	imp.rejected = append(imp.rejected, rejection{Record: number, Error: reason.Error(), Raw: raw})
+}
+
+// writeRejects writes the queued rejections of the records the progress
+// covers, in record order. Rejections of later records are dropped; the import
+// is stopping and a resumed run rejects them again.
+func (imp *importer) writeRejects() error {
+	sort.SliceStable(imp.rejected, func(i, j int) bool { return imp.rejected[i].Record < imp.rejected[j].Record })
+	for _, rejected := range imp.rejected {
+		if rejected.Record > imp.progress.Records {
+			break
+		}
+		line, _ := json.Marshal(rejected)
+		n, err := imp.opts.Rejects.Write(append(line, '\n'))
+		imp.progress.RejectsSize += int64(n)
+		if err != nil {
+			return fmt.Errorf("failed to write rejected record: %w", err)
+		}
+		imp.progress.Rejected++
+	}
+	imp.rejected = nil
+	return nil
+}
+
+// checkpoint writes the rejections covered by the current progress and then
+// atomically replaces the checkpoint file with the progress
+func (imp *importer) checkpoint() error {
+	if err := imp.writeRejects(); err != nil {
+		return err
+	}
+	if imp.opts.CheckpointPath == "" {
+		return nil
+	}
+	data, _ := json.Marshal(imp.progress)
+	tmp := imp.opts.CheckpointPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, imp.opts.CheckpointPath); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// LoadCheckpoint reads the progress of a previous run, if any
+func LoadCheckpoint(path string) (Progress, error) {
+	var progress Progress
+	if path == "" {
+		return progress, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return progress, fmt.Errorf("invalid checkpoint %s: %w", path, err)
+	}
+	return progress, nil
+}
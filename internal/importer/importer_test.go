@@ -0,0 +1,265 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"article-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// fakeStore records imported articles and fails batches containing failTitle
+// with failErr, a check constraint violation by default
+type fakeStore struct {
+	imported      []models.CreateArticleRequest
+	batches       int
+	authorLookups int
+	failTitle     string
+	failErr       error
+}
+
+func (s *fakeStore) GetAuthorsByIDs(ids []string) (map[string]*models.Author, error) {
+	s.authorLookups++
+	authors := map[string]*models.Author{}
+	for _, id := range ids {
+		if id == "author-1" || id == "author-2" {
+			authors[id] = &models.Author{ID: id}
+		}
+	}
+	return authors, nil
+}
+
+func (s *fakeStore) ImportArticles(reqs []models.CreateArticleRequest) ([]string, error) {
+	for _, req := range reqs {
+		if req.Title == s.failTitle {
+			if s.failErr != nil {
+				return nil, s.failErr
+			}
+			return nil, &pq.Error{Code: "23514", Message: "insert failed"}
+		}
+	}
+	s.batches++
+	s.imported = append(s.imported, reqs...)
+	ids := make([]string, len(reqs))
+	return ids, nil
+}
+
+func titles(reqs []models.CreateArticleRequest) []string {
+	var result []string
+	for _, req := range reqs {
+		result = append(result, req.Title)
+	}
+	return result
+}
+
+func TestRun_NDJSON(t *testing.T) {
+	input := strings.Join([]string{
+		`{"author_id": "author-1", "title": "One", "body": "Body", "tags": ["Go"]}`,
+		`{"author_id": "author-1", "title": "Broken"`,
+		``,
+		`{"author_id": "author-9", "title": "Orphan", "body": "Body"}`,
+		`{"author_id": "author-2", "title": "Two", "body": "Body"}`,
+		`{"author_id": "author-2", "title": "Fails", "body": "Body"}`,
+		`{"author_id": "author-2", "title": "", "body": "Body"}`,
+		`{"author_id": "author-1", "title": "Three", "body": "Body"}`,
+	}, "\n")
+
+	store := &fakeStore{failTitle: "Fails"}
+	var rejects bytes.Buffer
+	var reports []Progress
+	progress, err := Run(context.Background(), strings.NewReader(input), store, Options{
+		Format:     FormatNDJSON,
+		BatchSize:  2,
+		Rejects:    &rejects,
+		OnProgress: func(p Progress) { reports = append(reports, p) },
+	})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if progress != (Progress{Records: 7, Imported: 3, Rejected: 4, RejectsSize: int64(rejects.Len())}) {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+	if !reflect.DeepEqual(titles(store.imported), []string{"One", "Two", "Three"}) {
+		t.Errorf("Unexpected imported articles %v", titles(store.imported))
+	}
+	if store.imported[0].Tags[0] != "go" {
+		t.Errorf("Expected normalized tags, got %v", store.imported[0].Tags)
+	}
+	// Authors are only looked up for batches that introduce new ones
+	if store.authorLookups != 2 {
+		t.Errorf("Expected 2 author lookups, got %d", store.authorLookups)
+	}
+	if len(reports) == 0 || reports[len(reports)-1] != progress {
+		t.Errorf("Expected progress reports ending with %+v, got %+v", progress, reports)
+	}
+
+	lines := strings.Split(strings.TrimSpace(rejects.String()), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[0], `"record":2`) || !strings.Contains(lines[1], "author not found") {
+		t.Errorf("Unexpected rejected rows:\n%s", rejects.String())
+	}
+}
+
+func TestRun_CSV(t *testing.T) {
+	input := "author_id,title,body,tags,published_at\n" +
+		"author-1,First,\"Multi\nline body\",\"go, testing\",\n" +
+		"author-1,Second,Body,,not-a-date\n" +
+		"author-2,Third,Body\n"
+
+	store := &fakeStore{}
+	var rejects bytes.Buffer
+	progress, err := Run(context.Background(), strings.NewReader(input), store, Options{Format: FormatCSV, Rejects: &rejects})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if progress != (Progress{Records: 3, Imported: 1, Rejected: 2, RejectsSize: int64(rejects.Len())}) {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+	if store.imported[0].Body != "Multi\nline body" || !reflect.DeepEqual(store.imported[0].Tags, []string{"go", "testing"}) {
+		t.Errorf("Unexpected imported article %+v", store.imported[0])
+	}
+
+	if _, err := Run(context.Background(), strings.NewReader("author_id,password\n"), store, Options{Format: FormatCSV}); err == nil {
+		t.Error("Expected an error for an unknown CSV column")
+	}
+}
+
+func TestRun_ResumesFromCheckpoint(t *testing.T) {
+	input := strings.Join([]string{
+		`{"author_id": "author-1", "title": "One", "body": "Body"}`,
+		`{"author_id": "author-1", "title": "Two", "body": "Body"}`,
+		`{"author_id": "author-1", "title": "Three", "body": "Body"}`,
+	}, "\n")
+	checkpoint := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	// The first run is cancelled after its first batch
+	ctx, cancel := context.WithCancel(context.Background())
+	first := &fakeStore{}
+	_, err := Run(ctx, strings.NewReader(input), first, Options{
+		Format:         FormatNDJSON,
+		BatchSize:      1,
+		CheckpointPath: checkpoint,
+		OnProgress:     func(Progress) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the first run to be cancelled, got %v", err)
+	}
+
+	second := &fakeStore{}
+	progress, err := Run(context.Background(), strings.NewReader(input), second, Options{
+		Format:         FormatNDJSON,
+		BatchSize:      1,
+		CheckpointPath: checkpoint,
+	})
+	if err != nil {
+		t.Fatalf("Resumed import failed: %v", err)
+	}
+
+	imported := append(titles(first.imported), titles(second.imported)...)
+	if !reflect.DeepEqual(imported, []string{"One", "Two", "Three"}) {
+		t.Errorf("Expected every article imported exactly once, got %v", imported)
+	}
+	if progress.Records != 3 || progress.Imported != 3 {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+}
+
+func TestRun_StopsOnStoreFailure(t *testing.T) {
+	input := strings.Join([]string{
+		`{"author_id": "author-1", "title": "One", "body": "Body"}`,
+		`{"author_id": "author-1", "title": "Two", "body": "Body"}`,
+		`{"author_id": "author-1", "title": "Three", "body": "Body"}`,
+	}, "\n")
+	checkpoint := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	// A lost connection is not blamed on the rows
+	store := &fakeStore{failTitle: "Three", failErr: errors.New("connection reset by peer")}
+	var rejects bytes.Buffer
+	_, err := Run(context.Background(), strings.NewReader(input), store, Options{
+		Format:         FormatNDJSON,
+		BatchSize:      2,
+		CheckpointPath: checkpoint,
+		Rejects:        &rejects,
+	})
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("Expected the store error, got %v", err)
+	}
+	if rejects.Len() != 0 {
+		t.Errorf("Expected no rejected rows, got:\n%s", rejects.String())
+	}
+
+	progress, err := LoadCheckpoint(checkpoint)
+	if err != nil {
+		t.Fatalf("Failed to load checkpoint: %v", err)
+	}
+	if progress.Records != 2 || progress.Imported != 2 {
+		t.Errorf("Expected the checkpoint to stop before the failed batch, got %+v", progress)
+	}
+}
+
+func TestRun_RejectsFollowCheckpoint(t *testing.T) {
+	input := strings.Join([]string{
+		`{"author_id": "author-1", "title": "One", "body": "Body"}`,
+		`{"author_id": "author-1", "title": "Two", "body": "Body"}`,
+		`{"author_id": "author-1", "title": "Broken"`,
+		`{"author_id": "author-1", "title": "Three", "body": "Body"}`,
+		`{"author_id": "author-1", "title": "Also broken"`,
+	}, "\n")
+	checkpoint := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	// The run stops at the second batch; its rejected rows are not written
+	// since the resumed run reads them again
+	var rejects bytes.Buffer
+	failing := &fakeStore{failTitle: "Three", failErr: errors.New("connection reset by peer")}
+	options := Options{Format: FormatNDJSON, BatchSize: 2, CheckpointPath: checkpoint, Rejects: &rejects}
+	if _, err := Run(context.Background(), strings.NewReader(input), failing, options); err == nil {
+		t.Fatal("Expected the store error")
+	}
+	stopped, err := LoadCheckpoint(checkpoint)
+	if err != nil {
+		t.Fatalf("Failed to load checkpoint: %v", err)
+	}
+	if stopped.Records != 2 || stopped.RejectsSize != int64(rejects.Len()) {
+		t.Errorf("Expected the checkpoint to cover the written rejects, got %+v for %d bytes", stopped, rejects.Len())
+	}
+
+	rejects.Truncate(int(stopped.RejectsSize))
+	progress, err := Run(context.Background(), strings.NewReader(input), &fakeStore{}, options)
+	if err != nil {
+		t.Fatalf("Resumed import failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(rejects.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"record":3`) || !strings.Contains(lines[1], `"record":5`) {
+		t.Errorf("Expected each rejected row exactly once, got:\n%s", rejects.String())
+	}
+	if progress.Rejected != 2 || progress.RejectsSize != int64(rejects.Len()) {
+		t.Errorf("Unexpected progress %+v for %d bytes of rejects", progress, rejects.Len())
+	}
+}
+
+func TestRun_OverlongLine(t *testing.T) {
+	input := strings.Join([]string{
+		`{"author_id": "author-1", "title": "One", "body": "` + strings.Repeat("x", maxLineSize) + `"}`,
+		`{"author_id": "author-1", "title": "Two", "body": "Body"}`,
+	}, "\n")
+
+	store := &fakeStore{}
+	var rejects bytes.Buffer
+	progress, err := Run(context.Background(), strings.NewReader(input), store, Options{Format: FormatNDJSON, Rejects: &rejects})
+	if err != nil {
+		t.Fatalf("Expected the overlong line to be rejected, got %v", err)
+	}
+	if progress.Records != 2 || progress.Rejected != 1 || !reflect.DeepEqual(titles(store.imported), []string{"Two"}) {
+		t.Errorf("Unexpected progress %+v, imported %v", progress, titles(store.imported))
+	}
+	if !strings.Contains(rejects.String(), "line exceeds") || rejects.Len() > 2*maxRejectedLine {
+		t.Errorf("Expected a short rejection of the overlong line, got %d bytes", rejects.Len())
+	}
+}
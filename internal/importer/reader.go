@@ -0,0 +1,205 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"article-api/internal/models"
+)
+
+// Input formats accepted by the importer
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+)
+
+// maxLineSize bounds a single NDJSON record, which carries a whole article body
+const maxLineSize = 16 << 20
+
+// maxRejectedLine is how much of an overlong line is kept for the rejects output
+const maxRejectedLine = 1 << 10
+
+// record is one input row: the parsed article, or the reason it could not be parsed
+type record struct {
+	req models.CreateArticleRequest
+	raw string
+	err error
+}
+
+// recordReader streams records from an input file
+type recordReader interface {
+	// next returns the following record, or io.EOF when the input is exhausted
+	next() (record, error)
+}
+
+// newRecordReader returns a reader for the given input format
+func newRecordReader(r io.Reader, format string) (recordReader, error) {
+	switch format {
+	case FormatNDJSON:
+		return &ndjsonReader{reader: bufio.NewReaderSize(r, 64*1024)}, nil
+	case FormatCSV:
+		return newCSVReader(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q; use ndjson or csv", format)
+	}
+}
+
+// ndjsonReader reads one CreateArticleRequest JSON object per line, skipping
+// blank lines. Lines longer than maxLineSize are rejected.
+type ndjsonReader struct {
+	reader *bufio.Reader
+}
+
+func (r *ndjsonReader) next() (record, error) {
+	for {
+		line, tooLong, err := r.readLine()
+		if err != nil {
+			return record{}, err
+		}
+		if tooLong {
+			return record{raw: string(line), err: fmt.Errorf("line exceeds %d bytes", maxLineSize)}, nil
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		rec := record{raw: string(line)}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&rec.req); err != nil {
+			rec.err = fmt.Errorf("invalid JSON: %v", err)
+		}
+		return rec, nil
+	}
+}
+
+// readLine returns the next line without its newline, or io.EOF at the end of
+// the input. A line longer than maxLineSize is read to its end but only its
+// first maxRejectedLine bytes are returned, with tooLong set.
+func (r *ndjsonReader) readLine() (line []byte, tooLong bool, err error) {
+	for {
+		chunk, err := r.reader.ReadSlice('\n')
+		if !tooLong {
+			line = append(line, chunk...)
+			if len(line) > maxLineSize {
+				line, tooLong = append([]byte(nil), line[:maxRejectedLine]...), true
+			}
+		}
+
+		switch {
+		case err == bufio.ErrBufferFull:
+			continue
+		case err == io.EOF && (len(line) > 0 || tooLong):
+			return line, tooLong, nil
+		case err != nil:
+			return nil, false, err
+		}
+		if !tooLong {
+			line = line[:len(line)-1]
+		}
+		return line, tooLong, nil
+	}
+}
+
+// csvColumns are the columns a CSV import may contain; tags are comma-separated
+// within their cell and published_at is RFC 3339
+var csvColumns = map[string]bool{
+	"author_id": true, "title": true, "body": true, "content_format": true, "status": true, "published_at": true, "tags": true,
+}
+
+// csvReader reads articles from CSV with a header row naming the columns
+type csvReader struct {
+	reader  *csv.Reader
+	columns []string
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !csvColumns[name] {
+			return nil, fmt.Errorf("unknown CSV column %q", name)
+		}
+		columns[i] = name
+	}
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvReader) next() (record, error) {
+	fields, err := r.reader.Read()
+	if err == io.EOF {
+		return record{}, io.EOF
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return record{raw: strings.Join(fields, ","), err: fmt.Errorf("invalid CSV: %v", err)}, nil
+	}
+	if err != nil {
+		return record{}, err
+	}
+
+	rec := record{raw: csvLine(fields)}
+	if len(fields) != len(r.columns) {
+		rec.err = fmt.Errorf("expected %d columns, got %d", len(r.columns), len(fields))
+		return rec, nil
+	}
+
+	for i, value := range fields {
+		switch r.columns[i] {
+		case "author_id":
+			rec.req.AuthorID = value
+		case "title":
+			rec.req.Title = value
+		case "body":
+			rec.req.Body = value
+		case "content_format":
+			rec.req.ContentFormat = value
+		case "status":
+			rec.req.Status = value
+		case "published_at":
+			if value == "" {
+				continue
+			}
+			publishedAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				rec.err = fmt.Errorf("published_at must be an RFC 3339 timestamp")
+				return rec, nil
+			}
+			rec.req.PublishedAt = &publishedAt
+		case "tags":
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					rec.req.Tags = append(rec.req.Tags, tag)
+				}
+			}
+		}
+	}
+	return rec, nil
+}
+
+// csvLine re-encodes CSV fields as a single line for the rejected-rows file
+func csvLine(fields []string) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write(fields)
+	w.Flush()
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ScheduledPublisher is implemented by repositories that can publish scheduled articles
+type ScheduledPublisher interface {
+	PublishDueArticles(now time.Time) (int, error)
+}
+
+// StartPublishScheduler periodically flips scheduled articles to published once
+// their publication time has passed. It runs until ctx is cancelled.
+func StartPublishScheduler(ctx context.Context, publisher ScheduledPublisher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			publishDue(publisher)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// publishDue runs a single publishing pass and logs its outcome
+func publishDue(publisher ScheduledPublisher) {
+	published, err := publisher.PublishDueArticles(time.Now())
+	if err != nil {
+		log.Printf("Failed to publish scheduled articles: %v", err)
+		return
+	}
+	if published > 0 {
+		log.Printf("Published %d scheduled articles", published)
+	}
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakePublisher struct {
+	now   time.Time
+	calls int
+	err   error
+}
+
+func (f *fakePublisher) PublishDueArticles(now time.Time) (int, error) {
+	f.now = now
+	f.calls++
+	return 2, f.err
+}
+
+func TestPublishDue(t *testing.T) {
+	publisher := &fakePublisher{}
+
+	publishDue(publisher)
+
+	if publisher.calls != 1 {
+		t.Fatalf("Expected 1 publish call, got %d", publisher.calls)
+	}
+	if time.Since(publisher.now) > time.Minute {
+		t.Errorf("Expected publish time close to now, got %v", publisher.now)
+	}
+
+	// Errors are logged and do not stop the scheduler
+	publisher.err = errors.New("database unavailable")
+	publishDue(publisher)
+	if publisher.calls != 2 {
+		t.Errorf("Expected 2 publish calls, got %d", publisher.calls)
+	}
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TrashPurger is implemented by repositories that can hard-delete trashed articles
+type TrashPurger interface {
+	PurgeDeletedArticles(before time.Time) (int, error)
+}
+
+// StartTrashPurger periodically hard-deletes articles that have been in the
+// trash for longer than retention. It runs until ctx is cancelled.
+func StartTrashPurger(ctx context.Context, purger TrashPurger, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			purgeTrash(purger, retention)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// purgeTrash runs a single purge pass and logs its outcome
+func purgeTrash(purger TrashPurger, retention time.Duration) {
+	purged, err := purger.PurgeDeletedArticles(time.Now().Add(-retention))
+	if err != nil {
+		log.Printf("Failed to purge trashed articles: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged %d trashed articles older than %s", purged, retention)
+	}
+}
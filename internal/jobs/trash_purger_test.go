@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+type fakePurger struct {
+	before time.Time
+	calls  int
+}
+
+func (f *fakePurger) PurgeDeletedArticles(before time.Time) (int, error) {
+	f.before = before
+	f.calls++
+	return 1, nil
+}
+
+func TestPurgeTrash(t *testing.T) {
+	purger := &fakePurger{}
+	retention := 48 * time.Hour
+
+	purgeTrash(purger, retention)
+
+	if purger.calls != 1 {
+		t.Fatalf("Expected 1 purge call, got %d", purger.calls)
+	}
+
+	expected := time.Now().Add(-retention)
+	if diff := expected.Sub(purger.before); diff < 0 || diff > time.Minute {
+		t.Errorf("Expected cutoff close to %v, got %v", expected, purger.before)
+	}
+}
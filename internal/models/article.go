@@ -1,6 +1,44 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"article-api/internal/diff"
+)
+
+// Article lifecycle statuses
+const (
+	StatusDraft     = "draft"
+	StatusScheduled = "scheduled"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// IsValidStatus reports whether status is a known article lifecycle status
+func IsValidStatus(status string) bool {
+	switch status {
+	case StatusDraft, StatusScheduled, StatusPublished, StatusArchived:
+		return true
+	}
+	return false
+}
+
+// Article body formats
+const (
+	ContentFormatPlain    = "plain"
+	ContentFormatMarkdown = "markdown"
+	ContentFormatHTML     = "html"
+)
+
+// IsValidContentFormat reports whether format is a known article body format
+func IsValidContentFormat(format string) bool {
+	switch format {
+	case ContentFormatPlain, ContentFormatMarkdown, ContentFormatHTML:
+		return true
+	}
+	return false
+}
 
 // Author represents an author in the system
 type Author struct {
@@ -10,21 +48,60 @@ type Author struct {
 
 // Article represents an article in the system
 type Article struct {
-	ID        string    `json:"id"`
-	AuthorID  string    `json:"author_id"`
-	Title     string    `json:"title"`
-	Body      string    `json:"body"`
-	CreatedAt time.Time `json:"created_at"`
-	Author    *Author   `json:"author,omitempty"`
+	ID          string     `json:"id"`
+	AuthorID    string     `json:"author_id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Body        string     `json:"body"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Version     int        `json:"version"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Tags        []string   `json:"tags"`
+	Author      *Author    `json:"author,omitempty"`
+	// ContentFormat says how Body is written: plain, markdown or html
+	ContentFormat string `json:"content_format"`
+	// BodyHTML is the sanitized HTML rendering of Body, set on single-article responses
+	BodyHTML string `json:"body_html,omitempty"`
+	// Excerpt, WordCount and ReadingTimeMinutes are computed from the body on every write
+	Excerpt            string `json:"excerpt"`
+	WordCount          int    `json:"word_count"`
+	ReadingTimeMinutes int    `json:"reading_time_minutes"`
 }
 
 // ArticleListItem represents an article in list responses (without body for performance)
 type ArticleListItem struct {
-	ID        string    `json:"id"`
-	AuthorID  string    `json:"author_id"`
-	Title     string    `json:"title"`
-	CreatedAt time.Time `json:"created_at"`
-	Author    *Author   `json:"author,omitempty"`
+	ID          string     `json:"id"`
+	AuthorID    string     `json:"author_id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Tags        []string   `json:"tags"`
+	Author      *Author    `json:"author,omitempty"`
+	// Body is only fetched when requested through sparse fieldsets
+	Body               string `json:"body,omitempty"`
+	Excerpt            string `json:"excerpt,omitempty"`
+	WordCount          int    `json:"word_count,omitempty"`
+	ReadingTimeMinutes int    `json:"reading_time_minutes,omitempty"`
+	// UpdatedAt, ContentFormat and Version are only fetched when requested through sparse fieldsets
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+	ContentFormat string     `json:"content_format,omitempty"`
+	Version       int        `json:"version,omitempty"`
+	// Snippet and Rank are only set for search results
+	Snippet string  `json:"snippet,omitempty"`
+	Rank    float64 `json:"rank,omitempty"`
+}
+
+// RelatedArticle is an article listed as related to another, with its
+// similarity score; higher scores are more similar
+type RelatedArticle struct {
+	ArticleListItem
+	Score float64 `json:"score"`
 }
 
 // CreateArticleRequest represents the request payload for creating an article
@@ -32,4 +109,91 @@ type CreateArticleRequest struct {
 	AuthorID string `json:"author_id" validate:"required"`
 	Title    string `json:"title" validate:"required"`
 	Body     string `json:"body" validate:"required"`
+	// ContentFormat defaults to plain
+	ContentFormat string `json:"content_format,omitempty"`
+	// Status defaults to published; scheduled articles need a future PublishedAt
+	Status      string     `json:"status,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+}
+
+// Validate checks a create request like POST /articles does and normalizes
+// its tags. Imports apply the same checks.
+func (req *CreateArticleRequest) Validate() error {
+	if req.AuthorID == "" || req.Title == "" || req.Body == "" {
+		return fmt.Errorf("Missing required fields: author_id, title, body")
+	}
+	if err := ValidatePublication(req.Status, req.PublishedAt); err != nil {
+		return err
+	}
+	if err := ValidateContentFormat(req.ContentFormat); err != nil {
+		return err
+	}
+
+	tags, err := NormalizeTags(req.Tags)
+	if err != nil {
+		return err
+	}
+	req.Tags = tags
+	return nil
+}
+
+// ValidatePublication checks a requested lifecycle status and publication
+// time; an empty status keeps the default
+func ValidatePublication(status string, publishedAt *time.Time) error {
+	if status == "" {
+		return nil
+	}
+	if !IsValidStatus(status) {
+		return fmt.Errorf("status must be one of draft, scheduled, published, archived")
+	}
+	if status == StatusScheduled && (publishedAt == nil || !publishedAt.After(time.Now())) {
+		return fmt.Errorf("scheduled articles need a published_at in the future")
+	}
+	return nil
+}
+
+// ValidateContentFormat checks a requested body format; empty means the default
+func ValidateContentFormat(format string) error {
+	if format != "" && !IsValidContentFormat(format) {
+		return fmt.Errorf("content_format must be one of plain, markdown, html")
+	}
+	return nil
+}
+
+// UpdateArticleRequest represents the full replacement payload for updating an article
+type UpdateArticleRequest struct {
+	AuthorID string `json:"author_id" validate:"required"`
+	Title    string `json:"title" validate:"required"`
+	Body     string `json:"body" validate:"required"`
+	// ContentFormat is optional; when empty the current format is kept
+	ContentFormat string `json:"content_format,omitempty"`
+	// Status is optional; when empty the current status is kept
+	Status      string     `json:"status,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	// Tags replaces the article's tags; nil keeps them and an empty list clears them
+	Tags []string `json:"tags"`
+	// EditedBy identifies who made the change; it is taken from the X-Editor header
+	EditedBy string `json:"-"`
+}
+
+// ArticleRevision represents an immutable snapshot of an article's title and body
+type ArticleRevision struct {
+	ArticleID string    `json:"article_id"`
+	Revision  int       `json:"revision"`
+	AuthorID  string    `json:"author_id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	EditedBy  string    `json:"edited_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RevisionDiff represents the differences between two revisions of an article
+type RevisionDiff struct {
+	ArticleID string    `json:"article_id"`
+	From      int       `json:"from"`
+	To        int       `json:"to"`
+	Mode      string    `json:"mode"`
+	Title     []diff.Op `json:"title"`
+	Body      []diff.Op `json:"body"`
 }
@@ -0,0 +1,27 @@
+package models
+
+// Batch modes for creating several articles at once
+const (
+	// BatchModeAtomic creates every article or none of them
+	BatchModeAtomic = "atomic"
+	// BatchModePartial creates each valid article independently
+	BatchModePartial = "partial"
+)
+
+// BatchCreateArticlesRequest represents the request payload for creating articles in bulk
+type BatchCreateArticlesRequest struct {
+	Mode     string                 `json:"mode"`
+	Articles []CreateArticleRequest `json:"articles"`
+}
+
+// BatchItemResult reports the outcome of one item of a batch, in the manner of
+// a 207 Multi-Status response
+type BatchItemResult struct {
+	Index  int    `json:"index"`
+	Status int    `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Slug   string `json:"slug,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// DuplicateOf is the existing article a 409 item duplicates
+	DuplicateOf *DuplicateArticle `json:"duplicate_of,omitempty"`
+}
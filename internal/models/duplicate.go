@@ -0,0 +1,21 @@
+package models
+
+// How a new article matched an existing one
+const (
+	DuplicateMatchTitle   = "title"
+	DuplicateMatchBody    = "body"
+	DuplicateMatchSimilar = "similar"
+)
+
+// DuplicateArticle points at an existing article that a new one duplicates
+type DuplicateArticle struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+	Href  string `json:"href"`
+	// Match is title or body for identical normalized titles or bodies, and
+	// similar for trigram near-duplicates
+	Match string `json:"match"`
+	// Similarity is the lower of the title and body trigram similarities
+	Similarity float64 `json:"similarity"`
+}
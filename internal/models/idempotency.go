@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// IdempotencyRecord is the stored outcome of a request sent with an
+// Idempotency-Key, replayed when the request is retried
+type IdempotencyRecord struct {
+	// Fingerprint identifies the request payload the key was first used with
+	Fingerprint string `json:"fingerprint"`
+	// Status is zero while the request that reserved the key is still running
+	Status    int                 `json:"status"`
+	Header    map[string][]string `json:"header"`
+	Body      []byte              `json:"body"`
+	CreatedAt time.Time           `json:"created_at"`
+}
@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// SitemapEntry is a published article listed in the sitemap
+type SitemapEntry struct {
+	Slug    string
+	LastMod time.Time
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tag limits
+const (
+	MaxTagLength      = 50
+	MaxTagsPerArticle = 20
+)
+
+// Tag represents a tag with the number of articles using it
+type Tag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// RenameTagRequest represents the request payload for renaming a tag
+type RenameTagRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// MergeTagsRequest represents the request payload for merging tags into a target tag
+type MergeTagsRequest struct {
+	Sources []string `json:"sources" validate:"required"`
+	Target  string   `json:"target" validate:"required"`
+}
+
+// NormalizeTag lowercases a tag and collapses its whitespace
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+}
+
+// NormalizeTags normalizes, de-duplicates and validates a list of tags,
+// preserving the order in which they were first given
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		name := NormalizeTag(tag)
+		if name == "" || seen[name] {
+			continue
+		}
+		if len(name) > MaxTagLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", name, MaxTagLength)
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	if len(normalized) > MaxTagsPerArticle {
+		return nil, fmt.Errorf("articles can have at most %d tags", MaxTagsPerArticle)
+	}
+	return normalized, nil
+}
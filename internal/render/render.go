@@ -0,0 +1,84 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+
+	"article-api/internal/models"
+)
+
+// markdown renders GitHub Flavored Markdown. Raw HTML is passed through so the
+// sanitizer, not the renderer, decides what survives.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+)
+
+// policy is the allowlist of elements and attributes kept in rendered bodies.
+// It drops scripts, styles, event handlers and javascript: URLs and adds
+// rel="nofollow" to links.
+var policy = bluemonday.UGCPolicy()
+
+// strict strips every element, leaving the text of an HTML fragment
+var strict = bluemonday.StrictPolicy().AddSpaceWhenStrippingTag(true)
+
+// HTML renders an article body written in format as sanitized HTML. An empty
+// format is treated as plain text.
+func HTML(body, format string) (string, error) {
+	switch format {
+	case models.ContentFormatPlain, "":
+		return Text(body), nil
+	case models.ContentFormatMarkdown:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(body), &buf); err != nil {
+			return "", fmt.Errorf("failed to render markdown: %w", err)
+		}
+		return Sanitize(buf.String()), nil
+	case models.ContentFormatHTML:
+		return Sanitize(body), nil
+	}
+	return "", fmt.Errorf("unknown content format %q", format)
+}
+
+// PlainText returns the text of an article body written in format, without
+// its markup. An empty format is treated as plain text.
+func PlainText(body, format string) (string, error) {
+	if format == models.ContentFormatPlain || format == "" {
+		return body, nil
+	}
+	rendered, err := HTML(body, format)
+	if err != nil {
+		return "", err
+	}
+	return html.UnescapeString(strict.Sanitize(rendered)), nil
+}
+
+// Sanitize strips everything outside the allowlist from an HTML fragment
+func Sanitize(fragment string) string {
+	return policy.Sanitize(fragment)
+}
+
+// Text renders a plain-text body as HTML paragraphs, escaping its markup and
+// keeping single line breaks
+func Text(text string) string {
+	var b strings.Builder
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		lines := strings.Split(paragraph, "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(line)
+		}
+		b.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>")
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		body     string
+		format   string
+		expected string
+	}{
+		{"First line\nsecond <b>line</b>\n\nNext", "plain", "<p>First line<br>second &lt;b&gt;line&lt;/b&gt;</p><p>Next</p>"},
+		{"No format", "", "<p>No format</p>"},
+		{"# Title\n\nSome *emphasis* and `code`.", "markdown", "<h1>Title</h1>\n<p>Some <em>emphasis</em> and <code>code</code>.</p>\n"},
+		{"~~gone~~", "markdown", "<p><del>gone</del></p>\n"},
+		{"<p>Hello <strong>world</strong></p>", "html", "<p>Hello <strong>world</strong></p>"},
+	}
+
+	for _, tt := range tests {
+		got, err := HTML(tt.body, tt.format)
+		if err != nil {
+			t.Fatalf("HTML(%q, %q) failed: %v", tt.body, tt.format, err)
+		}
+		if got != tt.expected {
+			t.Errorf("HTML(%q, %q) = %q, expected %q", tt.body, tt.format, got, tt.expected)
+		}
+	}
+}
+
+func TestHTMLSanitizes(t *testing.T) {
+	attacks := []struct {
+		body   string
+		format string
+	}{
+		{"<script>alert(1)</script><p>ok</p>", "html"},
+		{`<img src="x.png" onerror="alert(1)">`, "html"},
+		{`<a href="javascript:alert(1)">link</a>`, "html"},
+		{`<iframe src="https://evil.example"></iframe>`, "html"},
+		{`<p style="background:url(javascript:alert(1))">styled</p>`, "html"},
+		{"Inline <script>alert(1)</script> markup", "markdown"},
+		{"[link](javascript:alert(1))", "markdown"},
+		{`<div onclick="alert(1)">click</div>`, "markdown"},
+	}
+
+	for _, tt := range attacks {
+		got, err := HTML(tt.body, tt.format)
+		if err != nil {
+			t.Fatalf("HTML(%q, %q) failed: %v", tt.body, tt.format, err)
+		}
+		lower := strings.ToLower(got)
+		for _, forbidden := range []string{"<script", "javascript:", "onerror", "onclick", "<iframe", "style="} {
+			if strings.Contains(lower, forbidden) {
+				t.Errorf("HTML(%q, %q) = %q, expected %s to be stripped", tt.body, tt.format, got, forbidden)
+			}
+		}
+	}
+}
+
+func TestHTMLKeepsSafeLinks(t *testing.T) {
+	got, err := HTML("[docs](https://example.com/docs)", "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, `href="https://example.com/docs"`) || !strings.Contains(got, `rel="nofollow"`) {
+		t.Errorf("Expected a nofollow link, got %q", got)
+	}
+}
+
+func TestHTMLUnknownFormat(t *testing.T) {
+	if _, err := HTML("body", "rtf"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		body     string
+		format   string
+		expected string
+	}{
+		{"Keep <b>this</b>", "plain", "Keep <b>this</b>"},
+		{"# Title\n\nSome **bold** &amp; text", "markdown", "Title Some bold & text"},
+		{"<p>One</p><p>Two<script>alert(1)</script></p>", "html", "One Two"},
+	}
+
+	for _, tt := range tests {
+		got, err := PlainText(tt.body, tt.format)
+		if err != nil {
+			t.Fatalf("PlainText(%q, %q) failed: %v", tt.body, tt.format, err)
+		}
+		if strings.Join(strings.Fields(got), " ") != tt.expected {
+			t.Errorf("PlainText(%q, %q) = %q, expected %q", tt.body, tt.format, got, tt.expected)
+		}
+	}
+}
@@ -3,50 +3,136 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"html"
 	"strings"
+	"sync"
 	"time"
 
 	"article-api/internal/cache"
+	"article-api/internal/config"
+	"article-api/internal/cursor"
 	"article-api/internal/models"
+	"article-api/internal/render"
+
+	"github.com/lib/pq"
 )
 
 // ArticleRepository handles database operations for articles
 type ArticleRepository struct {
-	db    *sql.DB
-	cache cache.CacheServiceInterface
+	db         *sql.DB
+	cache      cache.CacheServiceInterface
+	articleTTL int
+	listTTL    int
+	related    RelatedWeights
+	// duplicateThreshold is the trigram similarity above which a new article
+	// is a near-duplicate of one by the same author
+	duplicateThreshold float64
 }
 
 // NewArticleRepository creates a new article repository
 func NewArticleRepository(db *sql.DB, cacheService cache.CacheServiceInterface) *ArticleRepository {
+	cfg := config.LoadConfig()
+
 	return &ArticleRepository{
-		db:    db,
-		cache: cacheService,
+		db:         db,
+		cache:      cacheService,
+		articleTTL: cfg.Redis.ArticleTTL,
+		listTTL:    cfg.Redis.ListTTL,
+		related: RelatedWeights{
+			Tags:   cfg.App.RelatedTagWeight,
+			Author: cfg.App.RelatedAuthorWeight,
+			Title:  cfg.App.RelatedTitleWeight,
+		},
+		duplicateThreshold: cfg.App.DuplicateThreshold,
 	}
 }
 
-// ListArticles retrieves articles with search, filtering, and pagination
-func (r *ArticleRepository) ListArticles(params ListArticlesParams) (*ListArticlesResult, error) {
-	// Set defaults
-	if params.Page <= 0 {
-		params.Page = 1
+// listCachePrefix is the prefix shared by every cached article listing
+const listCachePrefix = "articles:list"
+
+// articleCacheKey returns the cache key of a single article
+func articleCacheKey(id string) string {
+	return fmt.Sprintf("article:%s", id)
+}
+
+// articleHTMLCacheKey returns the cache key of an article body rendered as
+// HTML. Each article has a single entry, replaced whenever a newer version is
+// rendered.
+func articleHTMLCacheKey(id string) string {
+	return fmt.Sprintf("article:%s:html", id)
+}
+
+// renderedBody is a cached HTML rendering of the body of an article version
+type renderedBody struct {
+	Version int    `json:"version"`
+	HTML    string `json:"html"`
+}
+
+// articleTagsColumn aggregates the tag names of the article aliased as "a"
+const articleTagsColumn = `COALESCE((
+	SELECT array_agg(t.name ORDER BY t.name)
+	FROM article_tags at
+	JOIN tags t ON t.id = at.tag_id
+	WHERE at.article_id = a.id
+), '{}') as tags`
+
+// headlineOptions configures ts_headline snippets for search results
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// highlightSnippet HTML-escapes a ts_headline snippet while keeping its <mark> highlights
+func highlightSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, "&lt;mark&gt;", "<mark>")
+	return strings.ReplaceAll(escaped, "&lt;/mark&gt;", "</mark>")
+}
+
+// invalidateListCaches drops every cached article listing
+func (r *ArticleRepository) invalidateListCaches() {
+	if cacheErr := r.cache.DeleteByPrefix(listCachePrefix); cacheErr != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Failed to invalidate cache: %v\n", cacheErr)
 	}
-	if params.Limit <= 0 {
-		params.Limit = 10
+}
+
+// dropArticleCaches drops the cached article and its rendered body
+func (r *ArticleRepository) dropArticleCaches(id string) {
+	if cacheErr := r.cache.Delete(articleCacheKey(id)); cacheErr != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Failed to invalidate article cache: %v\n", cacheErr)
 	}
-	if params.Limit > 100 {
-		params.Limit = 100 // Max limit
+	if cacheErr := r.cache.Delete(articleHTMLCacheKey(id)); cacheErr != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Failed to invalidate rendered article cache: %v\n", cacheErr)
 	}
+}
 
-	offset := (params.Page - 1) * params.Limit
+// invalidateArticleCaches drops the caches of an article and every cached
+// listing, including the related lists it may appear in
+func (r *ArticleRepository) invalidateArticleCaches(id string) {
+	r.dropArticleCaches(id)
+	r.invalidateListCaches()
+}
 
-	// Build WHERE clause
+// FeedCacheKey returns the cache key of a rendered feed. Feeds live under the
+// listing prefix, so every article change invalidates them with the listings.
+func FeedCacheKey(name string) string {
+	return fmt.Sprintf("%s:feed:%s", listCachePrefix, name)
+}
+
+// listConditions builds the WHERE conditions of a listing and their bind
+// arguments. When searching it also returns the tsquery expression, which
+// refers to the first argument.
+func listConditions(params ListArticlesParams) ([]string, []interface{}, string) {
 	whereConditions := []string{}
 	args := []interface{}{}
 	argIndex := 1
 
+	// Full-text search uses the weighted search_vector column and its GIN index
+	searchQuery := ""
 	if params.Search != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("(a.title ILIKE $%d OR a.body ILIKE $%d)", argIndex, argIndex))
-		args = append(args, "%"+params.Search+"%")
+		searchQuery = fmt.Sprintf("websearch_to_tsquery('english', $%d)", argIndex)
+		whereConditions = append(whereConditions, "a.search_vector @@ "+searchQuery)
+		args = append(args, params.Search)
 		argIndex++
 	}
 
@@ -56,6 +142,101 @@ func (r *ArticleRepository) ListArticles(params ListArticlesParams) (*ListArticl
 		argIndex++
 	}
 
+	if len(params.AuthorIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("a.author_id = ANY($%d)", argIndex))
+		args = append(args, pq.Array(params.AuthorIDs))
+		argIndex++
+	}
+
+	if len(params.ExcludeAuthorIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("NOT (a.author_id = ANY($%d))", argIndex))
+		args = append(args, pq.Array(params.ExcludeAuthorIDs))
+		argIndex++
+	}
+
+	// created_at is stored without a time zone, in UTC
+	if params.CreatedAfter != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("a.created_at > $%d::timestamp", argIndex))
+		args = append(args, params.CreatedAfter.UTC())
+		argIndex++
+	}
+
+	if params.CreatedBefore != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("a.created_at < $%d::timestamp", argIndex))
+		args = append(args, params.CreatedBefore.UTC())
+		argIndex++
+	}
+
+	if params.Tag != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM article_tags at JOIN tags t ON t.id = at.tag_id
+			WHERE at.article_id = a.id AND t.name = $%d
+		)`, argIndex))
+		args = append(args, params.Tag)
+		argIndex++
+	}
+
+	if len(params.TagsAny) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM article_tags at JOIN tags t ON t.id = at.tag_id
+			WHERE at.article_id = a.id AND t.name = ANY($%d)
+		)`, argIndex))
+		args = append(args, pq.Array(params.TagsAny))
+		argIndex++
+	}
+
+	if len(params.TagsAll) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf(`(
+			SELECT COUNT(DISTINCT t.name) FROM article_tags at JOIN tags t ON t.id = at.tag_id
+			WHERE at.article_id = a.id AND t.name = ANY($%d)
+		) = $%d`, argIndex, argIndex+1))
+		args = append(args, pq.Array(params.TagsAll), len(params.TagsAll))
+		argIndex += 2
+	}
+
+	// Only published articles are public
+	if !params.AllStatuses {
+		whereConditions = append(whereConditions, "a.status = 'published'")
+	}
+
+	if params.Status != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("a.status = $%d", argIndex))
+		args = append(args, params.Status)
+		argIndex++
+	}
+
+	// Trashed articles are hidden unless explicitly requested
+	switch {
+	case params.OnlyDeleted:
+		whereConditions = append(whereConditions, "a.deleted_at IS NOT NULL")
+	case !params.IncludeDeleted:
+		whereConditions = append(whereConditions, "a.deleted_at IS NULL")
+	}
+
+	return whereConditions, args, searchQuery
+}
+
+// ListArticles retrieves articles with search, filtering, and pagination
+func (r *ArticleRepository) ListArticles(params ListArticlesParams) (*ListArticlesResult, error) {
+	// Set defaults
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Limit > 100 {
+		params.Limit = 100 // Max limit
+	}
+	if len(params.Sort) == 0 {
+		params.Sort = DefaultSort
+	}
+
+	offset := (params.Page - 1) * params.Limit
+
+	whereConditions, args, searchQuery := listConditions(params)
+	argIndex := len(args) + 1
+
 	whereClause := ""
 	if len(whereConditions) > 0 {
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
@@ -75,23 +256,51 @@ func (r *ArticleRepository) ListArticles(params ListArticlesParams) (*ListArticl
 		return nil, fmt.Errorf("failed to count articles: %w", err)
 	}
 
-	// Articles query with pagination (excluding body for performance)
+	sortFields := params.Sort
+	relevance := SortsByRelevance(sortFields)
+	if relevance && (searchQuery == "" || params.Cursor != nil) {
+		return nil, fmt.Errorf("relevance sort requires a search term and offset pagination")
+	}
+
+	// Only the requested fields are fetched, plus the id and sort keys; search
+	// results can also carry a relevance rank and a highlighted body snippet
+	requested := params.Fields
+	if len(requested) == 0 {
+		requested = DefaultListFields
+	}
+	fields := selectedListFields(requested, sortFields, searchQuery != "")
+
+	// Keyset pagination continues from the cursor row instead of skipping rows,
+	// fetching one extra row to learn whether another page follows. Paging
+	// backward walks the reversed order and flips the page afterwards.
+	keys := sortKeys(sortFields, params.Cursor != nil && params.Cursor.Backward)
+	fetchLimit := params.Limit
+	if params.Cursor != nil {
+		if params.Cursor.Sort != FormatSort(sortFields) || len(params.Cursor.Values) != len(keys) {
+			return nil, fmt.Errorf("cursor does not match sort %q", FormatSort(sortFields))
+		}
+		condition, keysetArgs := keysetCondition(keys, params.Cursor.Values, argIndex)
+		whereConditions = append(whereConditions, condition)
+		args = append(args, keysetArgs...)
+		argIndex += len(keysetArgs)
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+		fetchLimit++
+		offset = 0
+	}
+	orderBy := orderByClause(keys)
+
+	// Articles query with pagination
 	articlesQuery := fmt.Sprintf(`
-		SELECT 
-			a.id, 
-			a.author_id, 
-			a.title, 
-			a.created_at,
-			au.id as author_id,
-			au.name as author_name
+		SELECT
+			%s
 		FROM articles a
 		LEFT JOIN authors au ON a.author_id = au.id
 		%s
-		ORDER BY a.created_at DESC
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argIndex, argIndex+1)
+	`, listColumns(fields, searchQuery), whereClause, orderBy, argIndex, argIndex+1)
 
-	args = append(args, params.Limit, offset)
+	args = append(args, fetchLimit, offset)
 
 	rows, err := r.db.Query(articlesQuery, args...)
 	if err != nil {
@@ -102,21 +311,11 @@ func (r *ArticleRepository) ListArticles(params ListArticlesParams) (*ListArticl
 	var articles []models.ArticleListItem
 	for rows.Next() {
 		var article models.ArticleListItem
-		var author models.Author
-
-		err := rows.Scan(
-			&article.ID,
-			&article.AuthorID,
-			&article.Title,
-			&article.CreatedAt,
-			&author.ID,
-			&author.Name,
-		)
-		if err != nil {
+		if err := rows.Scan(listDest(fields, &article)...); err != nil {
 			return nil, fmt.Errorf("failed to scan article: %w", err)
 		}
 
-		article.Author = &author
+		article.Snippet = highlightSnippet(article.Snippet)
 		articles = append(articles, article)
 	}
 
@@ -124,57 +323,618 @@ func (r *ArticleRepository) ListArticles(params ListArticlesParams) (*ListArticl
 		return nil, fmt.Errorf("error iterating articles: %w", err)
 	}
 
-	return &ListArticlesResult{
-		Articles: articles,
-		Total:    total,
-		Page:     params.Page,
-		Limit:    params.Limit,
-	}, nil
+	result := &ListArticlesResult{
+		Total: total,
+		Page:  params.Page,
+		Limit: params.Limit,
+	}
+
+	switch {
+	case params.Cursor != nil:
+		hasMore := len(articles) > params.Limit
+		if hasMore {
+			articles = articles[:params.Limit]
+		}
+		if params.Cursor.Backward {
+			for i, j := 0, len(articles)-1; i < j; i, j = i+1, j-1 {
+				articles[i], articles[j] = articles[j], articles[i]
+			}
+		}
+		// Paging backward always leaves the page we came from ahead, and vice versa
+		if len(articles) > 0 {
+			if hasMore || params.Cursor.Backward {
+				result.NextCursor = boundaryCursor(articles[len(articles)-1], sortFields, false)
+			}
+			if hasMore || !params.Cursor.Backward {
+				result.PrevCursor = boundaryCursor(articles[0], sortFields, true)
+			}
+		}
+	case !relevance && len(articles) > 0:
+		// Offset pages hand out cursors too, so clients can switch to keyset paging
+		if offset+len(articles) < total {
+			result.NextCursor = boundaryCursor(articles[len(articles)-1], sortFields, false)
+		}
+		if params.Page > 1 {
+			result.PrevCursor = boundaryCursor(articles[0], sortFields, true)
+		}
+	}
+
+	result.Articles = articles
+	return result, nil
+}
+
+// boundaryCursor returns a cursor that pages away from a listed article
+func boundaryCursor(article models.ArticleListItem, fields []SortField, backward bool) *cursor.Cursor {
+	return &cursor.Cursor{Sort: FormatSort(fields), Values: sortValues(article, fields), Backward: backward}
+}
+
+// articleColumns lists the columns read for a full article; queries alias the
+// article row as "a" and the author row as "au"
+const articleColumns = `
+	a.id,
+	a.author_id,
+	a.title,
+	a.slug,
+	a.body,
+	a.content_format,
+	a.excerpt,
+	a.word_count,
+	a.reading_time_minutes,
+	a.created_at,
+	a.updated_at,
+	a.version,
+	a.deleted_at,
+	a.status,
+	a.published_at,
+	` + articleTagsColumn + `,
+	au.id as author_id,
+	au.name as author_name
+`
+
+// contentFormat returns the format a new article's body is stored in
+func contentFormat(format string) string {
+	if format == "" {
+		return models.ContentFormatPlain
+	}
+	return format
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanArticle scans a row selected with articleColumns
+func scanArticle(row rowScanner) (*models.Article, error) {
+	var article models.Article
+	var author models.Author
+	err := row.Scan(
+		&article.ID,
+		&article.AuthorID,
+		&article.Title,
+		&article.Slug,
+		&article.Body,
+		&article.ContentFormat,
+		&article.Excerpt,
+		&article.WordCount,
+		&article.ReadingTimeMinutes,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+		&article.Version,
+		&article.DeletedAt,
+		&article.Status,
+		&article.PublishedAt,
+		pq.Array(&article.Tags),
+		&author.ID,
+		&author.Name,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	article.Author = &author
+	return &article, nil
 }
 
 // CreateArticle creates a new article
 func (r *ArticleRepository) CreateArticle(req models.CreateArticleRequest) (*models.Article, error) {
-	// Generate a simple ID (in production, you might want to use UUID)
-	id := fmt.Sprintf("article-%d", time.Now().UnixNano())
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	query := `
-		INSERT INTO articles (id, author_id, title, body, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, author_id, title, body, created_at
-	`
+	article, err := insertArticle(tx, req)
+	if err != nil {
+		return nil, err
+	}
 
-	var article models.Article
-	err := r.db.QueryRow(query, id, req.AuthorID, req.Title, req.Body, time.Now()).
-		Scan(&article.ID, &article.AuthorID, &article.Title, &article.Body, &article.CreatedAt)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit article: %w", err)
+	}
+
+	r.cacheCreatedArticle(article)
+
+	// Invalidate cache when new article is created
+	r.invalidateListCaches()
+
+	return article, nil
+}
+
+// CreateArticles inserts a batch of articles, returning the created article or
+// the error of each item in request order. In atomic mode every item shares one
+// transaction and the first failure rolls the whole batch back; otherwise each
+// item commits on its own. When unique is set every item gets the duplicate
+// check of CreateUniqueArticle, against existing articles and the earlier
+// items of the batch. List caches are invalidated once for the batch.
+func (r *ArticleRepository) CreateArticles(reqs []models.CreateArticleRequest, atomic, unique bool) ([]*models.Article, []error, error) {
+	articles := make([]*models.Article, len(reqs))
+	errs := make([]error, len(reqs))
+
+	if atomic {
+		tx, err := r.db.Begin()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		if unique {
+			authorIDs := make([]string, len(reqs))
+			for i, req := range reqs {
+				authorIDs[i] = req.AuthorID
+			}
+			if err := lockAuthors(tx, authorIDs); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		for i, req := range reqs {
+			article, err := r.insertCheckedArticle(tx, req, unique)
+			if err != nil {
+				for j := range reqs {
+					articles[j] = nil
+					errs[j] = &BatchAbortedError{Index: i}
+				}
+				errs[i] = err
+				return articles, errs, nil
+			}
+			articles[i] = article
+		}
+
+		if err := tx.Commit(); err != nil {
+			return nil, nil, fmt.Errorf("failed to commit articles: %w", err)
+		}
+	} else {
+		for i, req := range reqs {
+			articles[i], errs[i] = r.insertArticleTx(req, unique)
+		}
+	}
+
+	created := false
+	for _, article := range articles {
+		if article != nil {
+			r.cacheCreatedArticle(article)
+			created = true
+		}
+	}
+	if created {
+		r.invalidateListCaches()
+	}
+
+	return articles, errs, nil
+}
+
+// insertArticleTx inserts an article in a transaction of its own, checking it
+// for duplicates first when unique is set
+func (r *ArticleRepository) insertArticleTx(req models.CreateArticleRequest, unique bool) (*models.Article, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if unique {
+		if err := lockAuthors(tx, []string{req.AuthorID}); err != nil {
+			return nil, err
+		}
+	}
+
+	article, err := r.insertCheckedArticle(tx, req, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit article: %w", err)
+	}
+	return article, nil
+}
+
+var (
+	articleIDMu   sync.Mutex
+	lastArticleID int64
+)
+
+// nextArticleID returns a strictly increasing article ID, so IDs generated in
+// a tight loop or by concurrent requests never collide
+func nextArticleID() string {
+	articleIDMu.Lock()
+	defer articleIDMu.Unlock()
+
+	id := time.Now().UnixNano()
+	if id <= lastArticleID {
+		id = lastArticleID + 1
+	}
+	lastArticleID = id
+	return fmt.Sprintf("article-%d", id)
+}
+
+// insertArticle inserts an article with a unique slug and its tags within tx
+func insertArticle(tx *sql.Tx, req models.CreateArticleRequest) (*models.Article, error) {
+	id := nextArticleID()
+
+	now := time.Now()
+	status, publishedAt := initialPublication(req.Status, req.PublishedAt, now)
+
+	tags, err := models.NormalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	format := contentFormat(req.ContentFormat)
+	stats, err := articleStats(req.Body, format)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create article: %w", err)
 	}
 
-	// Fetch author information
-	authorQuery := `SELECT id, name FROM authors WHERE id = $1`
-	var author models.Author
-	err = r.db.QueryRow(authorQuery, req.AuthorID).
-		Scan(&author.ID, &author.Name)
+	query := fmt.Sprintf(`
+		WITH a AS (
+			INSERT INTO articles (id, author_id, title, slug, body, content_format, excerpt, word_count, reading_time_minutes, created_at, updated_at, status, published_at)
+			VALUES ($1, $2, $3, $4, $5, $9, $10, $11, $12, $6, $6, $7, $8)
+			RETURNING *
+		)
+		SELECT %s
+		FROM a
+		LEFT JOIN authors au ON a.author_id = au.id
+	`, articleColumns)
+
+	var article *models.Article
+	err = retrySlugConflicts(tx, func() error {
+		articleSlug, err := uniqueSlug(tx, req.Title, id)
+		if err != nil {
+			return err
+		}
+		row := tx.QueryRow(query, id, req.AuthorID, req.Title, articleSlug, req.Body, now, status, publishedAt,
+			format, stats.Excerpt, stats.WordCount, stats.ReadingTimeMinutes)
+		article, err = scanArticle(row)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch author: %w", err)
+		return nil, fmt.Errorf("failed to create article: %w", err)
 	}
 
-	article.Author = &author
+	if err := setArticleTags(tx, article.ID, tags); err != nil {
+		return nil, err
+	}
+	article.Tags = tags
 
-	// Cache the created article for 10 minutes (600 seconds)
-	cacheKey := fmt.Sprintf("article:%s", article.ID)
-	if cacheErr := r.cache.SetWithTTL(cacheKey, article, 600); cacheErr != nil {
+	return article, nil
+}
+
+// cacheCreatedArticle caches a new article using the configured article TTL
+func (r *ArticleRepository) cacheCreatedArticle(article *models.Article) {
+	if cacheErr := r.cache.SetWithTTL(articleCacheKey(article.ID), article, r.articleTTL); cacheErr != nil {
 		// Log error but don't fail the request
 		fmt.Printf("Failed to cache created article: %v\n", cacheErr)
 	}
+}
 
-	// Invalidate cache when new article is created
-	if cacheErr := r.cache.Delete("articles:list"); cacheErr != nil {
+// initialPublication resolves the status and publication time of a new article.
+// Articles are published immediately unless another status is requested.
+func initialPublication(status string, publishedAt *time.Time, now time.Time) (string, *time.Time) {
+	switch status {
+	case "":
+		return models.StatusPublished, &now
+	case models.StatusPublished:
+		if publishedAt == nil {
+			return status, &now
+		}
+		return status, publishedAt
+	case models.StatusScheduled:
+		return status, publishedAt
+	default:
+		return status, nil
+	}
+}
+
+// GetArticleByID retrieves a single article with its body, reading through the cache
+func (r *ArticleRepository) GetArticleByID(id string) (*models.Article, error) {
+	cacheKey := articleCacheKey(id)
+
+	var cached models.Article
+	if err := r.cache.Get(cacheKey, &cached); err == nil && cached.ID != "" {
+		return &cached, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM articles a
+		LEFT JOIN authors au ON a.author_id = au.id
+		WHERE a.id = $1 AND a.deleted_at IS NULL
+	`, articleColumns)
+
+	article, err := scanArticle(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &ArticleNotFoundError{}
+		}
+		return nil, fmt.Errorf("failed to get article: %w", err)
+	}
+
+	// Re-populate the cache so subsequent reads skip the database
+	if cacheErr := r.cache.SetWithTTL(cacheKey, article, r.articleTTL); cacheErr != nil {
 		// Log error but don't fail the request
-		fmt.Printf("Failed to invalidate cache: %v\n", cacheErr)
+		fmt.Printf("Failed to cache article: %v\n", cacheErr)
 	}
 
-	return &article, nil
+	return article, nil
+}
+
+// RenderArticleBody returns the body of an article as sanitized HTML, reading
+// through the cache. A cached rendering of another version is rendered again.
+func (r *ArticleRepository) RenderArticleBody(article *models.Article) (string, error) {
+	cacheKey := articleHTMLCacheKey(article.ID)
+
+	var cached renderedBody
+	if err := r.cache.Get(cacheKey, &cached); err == nil && cached.Version == article.Version {
+		return cached.HTML, nil
+	}
+
+	rendered, err := render.HTML(article.Body, article.ContentFormat)
+	if err != nil {
+		return "", err
+	}
+
+	cached = renderedBody{Version: article.Version, HTML: rendered}
+	if cacheErr := r.cache.SetWithTTL(cacheKey, cached, r.articleTTL); cacheErr != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Failed to cache rendered article: %v\n", cacheErr)
+	}
+
+	return rendered, nil
+}
+
+// UpdateArticle replaces the editable fields of an article. When expectedVersion is
+// greater than zero the update only succeeds if it matches the stored version.
+// An empty req.Status keeps the current status and publication time.
+func (r *ArticleRepository) UpdateArticle(id string, req models.UpdateArticleRequest, expectedVersion int) (*models.Article, error) {
+	query := fmt.Sprintf(`
+		WITH a AS (
+			UPDATE articles
+			SET author_id = $2,
+				title = $3,
+				body = $4,
+				content_format = $10,
+				excerpt = $11,
+				word_count = $12,
+				reading_time_minutes = $13,
+				updated_at = $5,
+				version = version + 1,
+				slug = $9,
+				status = COALESCE(NULLIF($7::text, ''), status),
+				published_at = CASE
+					WHEN $7::text = '' THEN published_at
+					WHEN $7::text = 'published' AND status = 'published' THEN COALESCE($8::timestamp, published_at)
+					WHEN $7::text = 'published' THEN COALESCE($8::timestamp, $5)
+					WHEN $7::text = 'scheduled' THEN $8::timestamp
+					WHEN $7::text = 'draft' THEN NULL
+					ELSE published_at
+				END
+			WHERE id = $1 AND deleted_at IS NULL AND ($6 = 0 OR version = $6)
+			RETURNING *
+		)
+		SELECT %s
+		FROM a
+		LEFT JOIN authors au ON a.author_id = au.id
+	`, articleColumns)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Let the revision trigger know who made the change
+	if req.EditedBy != "" {
+		if _, err := tx.Exec(`SELECT set_config('article_api.edited_by', $1, true)`, req.EditedBy); err != nil {
+			return nil, fmt.Errorf("failed to set editor: %w", err)
+		}
+	}
+
+	// A new title gets a new slug; the old one is kept in the slug history
+	var oldTitle, oldSlug, format string
+	err = tx.QueryRow(`SELECT title, slug, content_format FROM articles WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id).
+		Scan(&oldTitle, &oldSlug, &format)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &ArticleNotFoundError{}
+		}
+		return nil, fmt.Errorf("failed to lock article: %w", err)
+	}
+
+	if req.ContentFormat != "" {
+		format = req.ContentFormat
+	}
+	stats, err := articleStats(req.Body, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update article: %w", err)
+	}
+
+	newSlug := oldSlug
+	var article *models.Article
+	err = retrySlugConflicts(tx, func() error {
+		if req.Title != oldTitle {
+			if newSlug, err = uniqueSlug(tx, req.Title, id); err != nil {
+				return err
+			}
+		}
+		row := tx.QueryRow(query, id, req.AuthorID, req.Title, req.Body, time.Now(), expectedVersion, req.Status, req.PublishedAt, newSlug,
+			format, stats.Excerpt, stats.WordCount, stats.ReadingTimeMinutes)
+		article, err = scanArticle(row)
+		return err
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, r.updateMissError(id)
+		}
+		return nil, fmt.Errorf("failed to update article: %w", err)
+	}
+
+	if err := recordSlugChange(tx, id, oldSlug, newSlug); err != nil {
+		return nil, err
+	}
+
+	if req.Tags != nil {
+		tags, err := models.NormalizeTags(req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if err := setArticleTags(tx, id, tags); err != nil {
+			return nil, err
+		}
+		article.Tags = tags
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit article update: %w", err)
+	}
+
+	r.invalidateArticleCaches(id)
+
+	return article, nil
+}
+
+// updateMissError explains why an update touched no rows: either the article
+// does not exist or its version moved on
+func (r *ArticleRepository) updateMissError(id string) error {
+	var currentVersion int
+	err := r.db.QueryRow(`SELECT version FROM articles WHERE id = $1 AND deleted_at IS NULL`, id).Scan(&currentVersion)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &ArticleNotFoundError{}
+		}
+		return fmt.Errorf("failed to check article version: %w", err)
+	}
+	return &VersionConflictError{CurrentVersion: currentVersion}
+}
+
+// DeleteArticle moves an article to the trash by setting its deleted_at timestamp
+func (r *ArticleRepository) DeleteArticle(id string) error {
+	result, err := r.db.Exec(`UPDATE articles SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to delete article: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete article: %w", err)
+	}
+	if affected == 0 {
+		return &ArticleNotFoundError{}
+	}
+
+	r.invalidateArticleCaches(id)
+
+	return nil
+}
+
+// RestoreArticle takes an article out of the trash
+func (r *ArticleRepository) RestoreArticle(id string) (*models.Article, error) {
+	result, err := r.db.Exec(`UPDATE articles SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore article: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore article: %w", err)
+	}
+	if affected == 0 {
+		return nil, &ArticleNotFoundError{}
+	}
+
+	r.invalidateArticleCaches(id)
+
+	return r.GetArticleByID(id)
+}
+
+// PurgeDeletedArticles permanently removes articles trashed before the given time
+func (r *ArticleRepository) PurgeDeletedArticles(before time.Time) (int, error) {
+	rows, err := r.db.Query(`DELETE FROM articles WHERE deleted_at IS NOT NULL AND deleted_at < $1 RETURNING id`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge articles: %w", err)
+	}
+	defer rows.Close()
+
+	var purged []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("failed to scan purged article: %w", err)
+		}
+		purged = append(purged, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating purged articles: %w", err)
+	}
+
+	for _, id := range purged {
+		r.dropArticleCaches(id)
+	}
+	if len(purged) > 0 {
+		// Also drops the related lists the purged articles appeared in
+		r.invalidateListCaches()
+	}
+
+	return len(purged), nil
+}
+
+// PublishDueArticles publishes scheduled articles whose publication time has passed
+func (r *ArticleRepository) PublishDueArticles(now time.Time) (int, error) {
+	query := `
+		UPDATE articles
+		SET status = 'published', updated_at = $1, version = version + 1
+		WHERE status = 'scheduled' AND published_at <= $1 AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to publish scheduled articles: %w", err)
+	}
+	defer rows.Close()
+
+	var published []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("failed to scan published article: %w", err)
+		}
+		published = append(published, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating published articles: %w", err)
+	}
+
+	for _, id := range published {
+		r.dropArticleCaches(id)
+	}
+	if len(published) > 0 {
+		// Newly published articles join other articles' related lists
+		r.invalidateListCaches()
+	}
+
+	return len(published), nil
 }
 
 // GetAuthorByID retrieves an author by ID
@@ -193,3 +953,32 @@ func (r *ArticleRepository) GetAuthorByID(id string) (*models.Author, error) {
 
 	return &author, nil
 }
+
+// GetAuthorsByIDs retrieves the authors with the given IDs in one query, keyed
+// by ID. Unknown IDs are simply absent from the result.
+func (r *ArticleRepository) GetAuthorsByIDs(ids []string) (map[string]*models.Author, error) {
+	authors := map[string]*models.Author{}
+	if len(ids) == 0 {
+		return authors, nil
+	}
+
+	rows, err := r.db.Query(`SELECT id, name FROM authors WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var author models.Author
+		if err := rows.Scan(&author.ID, &author.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan author: %w", err)
+		}
+		authors[author.ID] = &author
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating authors: %w", err)
+	}
+
+	return authors, nil
+}
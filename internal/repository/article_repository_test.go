@@ -1,13 +1,19 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"article-api/internal/cache"
 	"article-api/internal/models"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 func setupTestDB(t *testing.T) *sql.DB {
@@ -129,6 +135,1130 @@ func TestArticleRepository_CreateArticle_InvalidAuthor(t *testing.T) {
 	}
 }
 
+func TestArticleRepository_GetArticleByID(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	article, err := repo.GetArticleByID("article-1")
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if article.Body == "" {
+		t.Error("Article should include its body")
+	}
+	if article.Author == nil || article.Author.ID != article.AuthorID {
+		t.Error("Article should have author information")
+	}
+
+	// Second read should be served from the cache
+	var cached models.Article
+	if err := mockCache.Get("article:article-1", &cached); err != nil {
+		t.Errorf("Expected article to be cached: %v", err)
+	}
+
+	_, err = repo.GetArticleByID("non-existent")
+	if _, ok := err.(*ArticleNotFoundError); !ok {
+		t.Errorf("Expected ArticleNotFoundError, got %v", err)
+	}
+}
+
+func TestArticleRepository_UpdateArticle(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	created, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Article",
+		Body:     "Original body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", created.ID)
+
+	req := models.UpdateArticleRequest{
+		AuthorID: "author-2",
+		Title:    "Updated Article",
+		Body:     "Updated body",
+	}
+	updated, err := repo.UpdateArticle(created.ID, req, created.Version)
+	if err != nil {
+		t.Fatalf("Failed to update article: %v", err)
+	}
+	if updated.Version != created.Version+1 {
+		t.Errorf("Expected version %d, got %d", created.Version+1, updated.Version)
+	}
+	if updated.Author == nil || updated.Author.ID != "author-2" {
+		t.Error("Updated article should have the new author information")
+	}
+
+	// Updating with the old version must be rejected
+	_, err = repo.UpdateArticle(created.ID, req, created.Version)
+	if _, ok := err.(*VersionConflictError); !ok {
+		t.Errorf("Expected VersionConflictError, got %v", err)
+	}
+
+	_, err = repo.UpdateArticle("non-existent", req, 0)
+	if _, ok := err.(*ArticleNotFoundError); !ok {
+		t.Errorf("Expected ArticleNotFoundError, got %v", err)
+	}
+}
+
+func TestArticleRepository_DeleteAndRestoreArticle(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	created, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Article",
+		Body:     "This is a test article body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", created.ID)
+
+	if err := repo.DeleteArticle(created.ID); err != nil {
+		t.Fatalf("Failed to delete article: %v", err)
+	}
+	if _, err := repo.GetArticleByID(created.ID); err == nil {
+		t.Error("Expected trashed article to be hidden")
+	}
+
+	result, err := repo.ListArticles(ListArticlesParams{Page: 1, Limit: 100, OnlyDeleted: true})
+	if err != nil {
+		t.Fatalf("Failed to list trash: %v", err)
+	}
+	found := false
+	for _, article := range result.Articles {
+		if article.ID == created.ID {
+			found = article.DeletedAt != nil
+		}
+	}
+	if !found {
+		t.Error("Expected trashed article in trash listing")
+	}
+
+	restored, err := repo.RestoreArticle(created.ID)
+	if err != nil {
+		t.Fatalf("Failed to restore article: %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Error("Restored article should not have deleted_at")
+	}
+}
+
+func TestArticleRepository_Revisions(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	created, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Article",
+		Body:     "Original body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", created.ID)
+
+	_, err = repo.UpdateArticle(created.ID, models.UpdateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Article",
+		Body:     "Edited body",
+		EditedBy: "editor-1",
+	}, 0)
+	if err != nil {
+		t.Fatalf("Failed to update article: %v", err)
+	}
+
+	revisions, err := repo.ListRevisions(created.ID)
+	if err != nil {
+		t.Fatalf("Failed to list revisions: %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("Expected 2 revisions, got %d", len(revisions))
+	}
+	if revisions[0].Revision != 2 || revisions[0].EditedBy != "editor-1" {
+		t.Errorf("Unexpected latest revision: %+v", revisions[0])
+	}
+
+	first, err := repo.GetRevision(created.ID, 1)
+	if err != nil {
+		t.Fatalf("Failed to get revision: %v", err)
+	}
+	if first.Body != "Original body" || first.EditedBy != "author-1" {
+		t.Errorf("Unexpected first revision: %+v", first)
+	}
+
+	if _, err := db.Exec("UPDATE article_revisions SET body = 'tampered' WHERE article_id = $1", created.ID); err == nil {
+		t.Error("Expected revisions to be immutable")
+	}
+}
+
+func TestArticleRepository_PublishDueArticles(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	publishAt := time.Now().Add(time.Hour)
+	created, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID:    "author-1",
+		Title:       "Test Article",
+		Body:        "Scheduled body",
+		Status:      models.StatusScheduled,
+		PublishedAt: &publishAt,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", created.ID)
+
+	if created.Status != models.StatusScheduled {
+		t.Errorf("Expected status %s, got %s", models.StatusScheduled, created.Status)
+	}
+
+	// Public listings only contain published articles
+	result, err := repo.ListArticles(ListArticlesParams{Page: 1, Limit: 100})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	for _, article := range result.Articles {
+		if article.ID == created.ID {
+			t.Error("Scheduled article should not be listed publicly")
+		}
+	}
+
+	if _, err := repo.PublishDueArticles(publishAt.Add(time.Minute)); err != nil {
+		t.Fatalf("Failed to publish due articles: %v", err)
+	}
+
+	published, err := repo.GetArticleByID(created.ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if published.Status != models.StatusPublished {
+		t.Errorf("Expected status %s, got %s", models.StatusPublished, published.Status)
+	}
+}
+
+func TestArticleRepository_Slugs(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	req := models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Slug Collisions",
+		Body:     "This is a test article body",
+	}
+	first, err := repo.CreateArticle(req)
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", first.ID)
+
+	second, err := repo.CreateArticle(req)
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", second.ID)
+
+	if first.Slug != "test-slug-collisions" || second.Slug != "test-slug-collisions-2" {
+		t.Errorf("Unexpected slugs %s and %s", first.Slug, second.Slug)
+	}
+
+	updated, err := repo.UpdateArticle(first.ID, models.UpdateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Slug Renamed",
+		Body:     "This is a test article body",
+	}, 0)
+	if err != nil {
+		t.Fatalf("Failed to update article: %v", err)
+	}
+	if updated.Slug != "test-slug-renamed" {
+		t.Errorf("Expected slug test-slug-renamed, got %s", updated.Slug)
+	}
+
+	// The previous slug still resolves to the same article
+	article, err := repo.GetArticleBySlug("test-slug-collisions")
+	if err != nil {
+		t.Fatalf("Failed to get article by old slug: %v", err)
+	}
+	if article.ID != first.ID || article.Slug != "test-slug-renamed" {
+		t.Errorf("Expected old slug to resolve to %s with canonical slug, got %s (%s)", first.ID, article.ID, article.Slug)
+	}
+}
+
+func TestArticleRepository_SlugCollisions(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	// Long titles are shortened to fit a suffix, and concurrent creates race
+	// for the same candidate; every article still gets its own slug
+	title := "Test " + strings.Repeat("very long title ", 10)
+	results := make(chan *models.Article, 5)
+	errs := make(chan error, 5)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			article, err := repo.CreateArticle(models.CreateArticleRequest{
+				AuthorID: "author-1",
+				Title:    title,
+				Body:     "This is a test article body",
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			results <- article
+		}()
+	}
+	wg.Wait()
+	close(results)
+	close(errs)
+
+	slugs := map[string]bool{}
+	for article := range results {
+		defer db.Exec("DELETE FROM articles WHERE id = $1", article.ID)
+		if slugs[article.Slug] {
+			t.Errorf("Slug %s was given out twice", article.Slug)
+		}
+		slugs[article.Slug] = true
+	}
+	for err := range errs {
+		t.Errorf("Failed to create article: %v", err)
+	}
+	if len(slugs) != 5 {
+		t.Errorf("Expected 5 distinct slugs, got %v", slugs)
+	}
+}
+
+func TestArticleRepository_Tags(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+	tagRepo := NewTagRepository(db, mockCache)
+
+	created, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Tagged Article",
+		Body:     "This is a test article body",
+		Tags:     []string{"test-go", "test-web"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", created.ID)
+	defer db.Exec("DELETE FROM tags WHERE name LIKE 'test-%'")
+
+	result, err := repo.ListArticles(ListArticlesParams{Page: 1, Limit: 10, TagsAll: []string{"test-go", "test-web"}})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(result.Articles) != 1 || len(result.Articles[0].Tags) != 2 {
+		t.Fatalf("Expected the tagged article with its tags, got %+v", result.Articles)
+	}
+
+	tag, err := tagRepo.MergeTags([]string{"test-web"}, "test-go")
+	if err != nil {
+		t.Fatalf("Failed to merge tags: %v", err)
+	}
+	if tag.Count != 1 {
+		t.Errorf("Expected merged tag to be used once, got %d", tag.Count)
+	}
+
+	article, err := repo.GetArticleByID(created.ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if len(article.Tags) != 1 || article.Tags[0] != "test-go" {
+		t.Errorf("Expected tags [test-go] after merge, got %v", article.Tags)
+	}
+
+	if _, err := tagRepo.RenameTag("test-missing", "test-other"); err == nil {
+		t.Error("Expected error when renaming a missing tag")
+	}
+}
+
+func TestArticleRepository_ListArticles_FullTextSearch(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	titleMatch, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Zymurgy Handbook",
+		Body:     "Everything about brewing.",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", titleMatch.ID)
+
+	bodyMatch, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-2",
+		Title:    "Test Brewing Notes",
+		Body:     "A short note that mentions zymurgy once.",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", bodyMatch.ID)
+
+	result, err := repo.ListArticles(ListArticlesParams{Search: "zymurgy", Sort: []SortField{{Field: SortRelevance, Desc: true}}, Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("Failed to search articles: %v", err)
+	}
+	if len(result.Articles) != 2 {
+		t.Fatalf("Expected 2 search results, got %d", len(result.Articles))
+	}
+
+	// Title matches (weight A) rank above body matches (weight B)
+	if result.Articles[0].ID != titleMatch.ID {
+		t.Errorf("Expected title match first, got %s", result.Articles[0].ID)
+	}
+	if !strings.Contains(result.Articles[1].Snippet, "<mark>zymurgy</mark>") {
+		t.Errorf("Expected highlighted snippet, got %q", result.Articles[1].Snippet)
+	}
+}
+
+func TestArticleRepository_ListArticles_Cursor(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	var ids []string
+	for i := 1; i <= 3; i++ {
+		article, err := repo.CreateArticle(models.CreateArticleRequest{
+			AuthorID: "author-1",
+			Title:    fmt.Sprintf("Test Cursor Article %d", i),
+			Body:     "Keyset pagination body",
+			Tags:     []string{"cursor-test"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+		defer db.Exec("DELETE FROM articles WHERE id = $1", article.ID)
+		ids = append(ids, article.ID)
+	}
+
+	first, err := repo.ListArticles(ListArticlesParams{Tag: "cursor-test", Page: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(first.Articles) != 2 || first.NextCursor == nil || first.PrevCursor != nil {
+		t.Fatalf("Unexpected first page: %d articles, next %v, prev %v", len(first.Articles), first.NextCursor, first.PrevCursor)
+	}
+
+	// A row inserted after the first page must not shift the next page
+	newer, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Cursor Article 4",
+		Body:     "Keyset pagination body",
+		Tags:     []string{"cursor-test"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", newer.ID)
+
+	second, err := repo.ListArticles(ListArticlesParams{Tag: "cursor-test", Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(second.Articles) != 1 || second.Articles[0].ID != ids[0] || second.NextCursor != nil {
+		t.Fatalf("Expected only the oldest article on the last page, got %+v", second.Articles)
+	}
+
+	// Paging back returns the original first page in order
+	back, err := repo.ListArticles(ListArticlesParams{Tag: "cursor-test", Limit: 2, Cursor: second.PrevCursor})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(back.Articles) != 2 || back.Articles[0].ID != first.Articles[0].ID || back.Articles[1].ID != first.Articles[1].ID {
+		t.Errorf("Expected to page back to the first page, got %+v", back.Articles)
+	}
+	if back.PrevCursor == nil {
+		t.Error("Expected a previous cursor towards the newer article")
+	}
+}
+
+func TestArticleRepository_ListArticles_Sort(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	for _, title := range []string{"Test Sort Bravo", "Test Sort Charlie", "Test Sort Alpha"} {
+		article, err := repo.CreateArticle(models.CreateArticleRequest{
+			AuthorID: "author-1",
+			Title:    title,
+			Body:     "Sort body",
+			Tags:     []string{"sort-test"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+		defer db.Exec("DELETE FROM articles WHERE id = $1", article.ID)
+	}
+
+	sort, _ := ParseSort("-title")
+	first, err := repo.ListArticles(ListArticlesParams{Tag: "sort-test", Sort: sort, Limit: 2})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(first.Articles) != 2 || first.Articles[0].Title != "Test Sort Charlie" || first.Articles[1].Title != "Test Sort Bravo" {
+		t.Fatalf("Unexpected first page: %+v", first.Articles)
+	}
+
+	second, err := repo.ListArticles(ListArticlesParams{Tag: "sort-test", Sort: sort, Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(second.Articles) != 1 || second.Articles[0].Title != "Test Sort Alpha" {
+		t.Errorf("Unexpected second page: %+v", second.Articles)
+	}
+
+	// A cursor only continues the sort it was issued for
+	if _, err := repo.ListArticles(ListArticlesParams{Tag: "sort-test", Limit: 2, Cursor: first.NextCursor}); err == nil {
+		t.Error("Expected an error for a cursor from another sort")
+	}
+}
+
+func TestArticleRepository_ListArticles_AuthorAndDateFilters(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	start := time.Now().UTC().Add(-time.Minute)
+	for _, authorID := range []string{"author-1", "author-2"} {
+		article, err := repo.CreateArticle(models.CreateArticleRequest{
+			AuthorID: authorID,
+			Title:    "Test Filter Article",
+			Body:     "Filter body",
+			Tags:     []string{"filter-test"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+		defer db.Exec("DELETE FROM articles WHERE id = $1", article.ID)
+	}
+
+	result, err := repo.ListArticles(ListArticlesParams{Tag: "filter-test", AuthorIDs: []string{"author-1", "author-2"}, ExcludeAuthorIDs: []string{"author-2"}})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(result.Articles) != 1 || result.Articles[0].AuthorID != "author-1" {
+		t.Errorf("Expected only author-1's article, got %+v", result.Articles)
+	}
+
+	result, err = repo.ListArticles(ListArticlesParams{Tag: "filter-test", CreatedAfter: &start})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if result.Total != 2 {
+		t.Errorf("Expected 2 articles created after %v, got %d", start, result.Total)
+	}
+	result, err = repo.ListArticles(ListArticlesParams{Tag: "filter-test", CreatedBefore: &start})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if result.Total != 0 {
+		t.Errorf("Expected no articles created before %v, got %d", start, result.Total)
+	}
+}
+
+func TestArticleRepository_ListArticles_Fields(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	article, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Test Fields Article",
+		Body:     "Sparse   fieldset\nbody",
+		Tags:     []string{"fields-test"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", article.ID)
+
+	result, err := repo.ListArticles(ListArticlesParams{Tag: "fields-test", Fields: []string{"title", "body", "excerpt"}})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(result.Articles) != 1 {
+		t.Fatalf("Expected 1 article, got %d", len(result.Articles))
+	}
+
+	listed := result.Articles[0]
+	if listed.ID != article.ID || listed.Body != article.Body || listed.Excerpt != "Sparse fieldset body" {
+		t.Errorf("Unexpected fields: %+v", listed)
+	}
+	if listed.Slug != "" || listed.Tags != nil || listed.Author != nil {
+		t.Errorf("Expected unrequested fields to be left empty, got %+v", listed)
+	}
+}
+
+func TestArticleRepository_CreateArticles(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	reqs := []models.CreateArticleRequest{
+		{AuthorID: "author-1", Title: "Test Batch Article", Body: "Batch body"},
+		{AuthorID: "missing-author", Title: "Test Batch Orphan", Body: "Batch body"},
+	}
+
+	// The foreign key failure rolls back the whole atomic batch
+	articles, errs, err := repo.CreateArticles(reqs, true, true)
+	if err != nil {
+		t.Fatalf("Failed to run batch: %v", err)
+	}
+	if articles[0] != nil || errs[1] == nil {
+		t.Fatalf("Expected the atomic batch to fail, got %+v / %v", articles, errs)
+	}
+	if _, ok := errs[0].(*BatchAbortedError); !ok {
+		t.Errorf("Expected the valid item to be rolled back, got %v", errs[0])
+	}
+	var count int
+	db.QueryRow("SELECT COUNT(*) FROM articles WHERE title = 'Test Batch Article'").Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected no articles after rollback, found %d", count)
+	}
+
+	// Partial batches keep the items that succeed
+	articles, errs, err = repo.CreateArticles(reqs, false, true)
+	if err != nil {
+		t.Fatalf("Failed to run batch: %v", err)
+	}
+	if articles[0] == nil || errs[0] != nil || errs[1] == nil {
+		t.Fatalf("Expected only the first item to be created, got %+v / %v", articles, errs)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", articles[0].ID)
+
+	authors, err := repo.GetAuthorsByIDs([]string{"author-1", "missing-author"})
+	if err != nil {
+		t.Fatalf("Failed to get authors: %v", err)
+	}
+	if len(authors) != 1 || authors["author-1"] == nil {
+		t.Errorf("Expected only author-1, got %v", authors)
+	}
+}
+
+func TestArticleRepository_ImportArticles(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	ids, err := repo.ImportArticles([]models.CreateArticleRequest{
+		{AuthorID: "author-1", Title: "Test Imported Article", Body: "Imported body", Tags: []string{"import-test"}},
+		{AuthorID: "author-2", Title: "Test Imported Article", Body: "Imported body", Status: models.StatusDraft},
+	})
+	if err != nil {
+		t.Fatalf("Failed to import articles: %v", err)
+	}
+	for _, id := range ids {
+		defer db.Exec("DELETE FROM articles WHERE id = $1", id)
+	}
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatalf("Expected 2 distinct IDs, got %v", ids)
+	}
+
+	first, err := repo.GetArticleByID(ids[0])
+	if err != nil {
+		t.Fatalf("Failed to get imported article: %v", err)
+	}
+	second, err := repo.GetArticleByID(ids[1])
+	if err != nil {
+		t.Fatalf("Failed to get imported article: %v", err)
+	}
+	if first.Slug == second.Slug {
+		t.Errorf("Expected unique slugs within a batch, got %q twice", first.Slug)
+	}
+	if len(first.Tags) != 1 || first.Tags[0] != "import-test" || second.Status != models.StatusDraft {
+		t.Errorf("Unexpected imported articles: %+v / %+v", first, second)
+	}
+
+	// A bad row fails the whole batch
+	if _, err := repo.ImportArticles([]models.CreateArticleRequest{
+		{AuthorID: "author-1", Title: "Test Import Rollback", Body: "Body"},
+		{AuthorID: "missing-author", Title: "Test Import Rollback", Body: "Body"},
+	}); err == nil {
+		t.Error("Expected the batch with an unknown author to fail")
+	}
+	var count int
+	db.QueryRow("SELECT COUNT(*) FROM articles WHERE title = 'Test Import Rollback'").Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected no articles from the failed batch, found %d", count)
+	}
+}
+
+func TestArticleRepository_ExportArticles(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	// More rows than one cursor fetch, so the export spans several FETCHes
+	reqs := make([]models.CreateArticleRequest, exportFetchSize+3)
+	for i := range reqs {
+		reqs[i] = models.CreateArticleRequest{AuthorID: "author-1", Title: fmt.Sprintf("Test Export %04d", i), Body: "Export body", Tags: []string{"export-test"}}
+	}
+	ids, err := repo.ImportArticles(reqs)
+	if err != nil {
+		t.Fatalf("Failed to import articles: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = ANY($1)", pq.Array(ids))
+
+	sort, _ := ParseSort("title")
+	var titles []string
+	err = repo.ExportArticles(context.Background(), ListArticlesParams{Tag: "export-test", Sort: sort}, func(article *models.Article) error {
+		titles = append(titles, article.Title)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to export articles: %v", err)
+	}
+	if len(titles) != len(reqs) || titles[0] != "Test Export 0000" || titles[len(titles)-1] != reqs[len(reqs)-1].Title {
+		t.Errorf("Expected %d articles in title order, got %d", len(reqs), len(titles))
+	}
+
+	// An error from the callback stops the export
+	stop := errors.New("stop")
+	count := 0
+	err = repo.ExportArticles(context.Background(), ListArticlesParams{Tag: "export-test"}, func(article *models.Article) error {
+		count++
+		return stop
+	})
+	if err != stop || count != 1 {
+		t.Errorf("Expected the export to stop after the first article, got %d (%v)", count, err)
+	}
+}
+
+func TestArticleRepository_Sitemap(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(db, mockCache)
+
+	before, err := repo.SitemapPages(1)
+	if err != nil {
+		t.Fatalf("Failed to query sitemap pages: %v", err)
+	}
+
+	ids, err := repo.ImportArticles([]models.CreateArticleRequest{
+		{AuthorID: "author-1", Title: "Test Sitemap Published", Body: "Body"},
+		{AuthorID: "author-1", Title: "Test Sitemap Draft", Body: "Body", Status: models.StatusDraft},
+	})
+	if err != nil {
+		t.Fatalf("Failed to import articles: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = ANY($1)", pq.Array(ids))
+
+	// Only the published article is listed, on a page of its own
+	pages, err := repo.SitemapPages(1)
+	if err != nil {
+		t.Fatalf("Failed to query sitemap pages: %v", err)
+	}
+	if len(pages) != len(before)+1 {
+		t.Fatalf("Expected one more sitemap page, got %d then %d", len(before), len(pages))
+	}
+	entries, err := repo.SitemapEntries(len(pages), 1)
+	if err != nil {
+		t.Fatalf("Failed to query sitemap entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Slug != "test-sitemap-published" || !entries[0].LastMod.Equal(pages[len(pages)-1]) {
+		t.Errorf("Unexpected sitemap entries %+v", entries)
+	}
+}
+
+func TestIdempotencyRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewIdempotencyRepository(db)
+	key := fmt.Sprintf("test-key-%d", time.Now().UnixNano())
+	defer db.Exec("DELETE FROM idempotency_keys WHERE key = $1", key)
+
+	var notFound *IdempotencyKeyNotFoundError
+	if _, err := repo.GetIdempotencyRecord(key); !errors.As(err, &notFound) {
+		t.Fatalf("Expected IdempotencyKeyNotFoundError, got %v", err)
+	}
+
+	// A key can be reserved once until it is released
+	for _, expected := range []bool{true, false} {
+		if reserved, err := repo.ReserveIdempotencyKey(key, "abc"); err != nil || reserved != expected {
+			t.Fatalf("Expected reserved to be %v, got %v, %v", expected, reserved, err)
+		}
+	}
+	if pending, err := repo.GetIdempotencyRecord(key); err != nil || pending.Status != 0 {
+		t.Fatalf("Expected a pending record, got %+v, %v", pending, err)
+	}
+	if err := repo.ReleaseIdempotencyKey(key); err != nil {
+		t.Fatalf("Failed to release idempotency key: %v", err)
+	}
+	if reserved, err := repo.ReserveIdempotencyKey(key, "abc"); err != nil || !reserved {
+		t.Fatalf("Expected a released key to be reserved again, got %v, %v", reserved, err)
+	}
+
+	record := models.IdempotencyRecord{
+		Fingerprint: "abc",
+		Status:      201,
+		Header:      map[string][]string{"Content-Type": {"application/json"}},
+		Body:        []byte(`{"id":"article-1"}`),
+		CreatedAt:   time.Now(),
+	}
+	if err := repo.SaveIdempotencyRecord(key, record); err != nil {
+		t.Fatalf("Failed to save idempotency record: %v", err)
+	}
+
+	stored, err := repo.GetIdempotencyRecord(key)
+	if err != nil {
+		t.Fatalf("Failed to get idempotency record: %v", err)
+	}
+	if stored.Fingerprint != "abc" || stored.Status != 201 || string(stored.Body) != string(record.Body) || stored.Header["Content-Type"][0] != "application/json" {
+		t.Errorf("Unexpected idempotency record %+v", stored)
+	}
+
+	// Expired records are ignored
+	db.Exec("UPDATE idempotency_keys SET created_at = $1 WHERE key = $2", time.Now().Add(-IdempotencyTTL-time.Minute), key)
+	if _, err := repo.GetIdempotencyRecord(key); !errors.As(err, &notFound) {
+		t.Errorf("Expected an expired record to be ignored, got %v", err)
+	}
+}
+
+func TestArticleRepository_ContentFormat(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewArticleRepository(db, cache.NewMockCacheService())
+
+	created, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID:      "author-1",
+		Title:         "Markdown Article",
+		Body:          "Some **bold** text",
+		ContentFormat: models.ContentFormatMarkdown,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", created.ID)
+
+	if created.ContentFormat != models.ContentFormatMarkdown {
+		t.Errorf("Expected content format %q, got %q", models.ContentFormatMarkdown, created.ContentFormat)
+	}
+
+	// An update without a format keeps the current one
+	updated, err := repo.UpdateArticle(created.ID, models.UpdateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Markdown Article",
+		Body:     "More **bold** text",
+	}, 0)
+	if err != nil {
+		t.Fatalf("Failed to update article: %v", err)
+	}
+	if updated.ContentFormat != models.ContentFormatMarkdown {
+		t.Errorf("Expected the content format to be kept, got %q", updated.ContentFormat)
+	}
+}
+
+func TestArticleRepository_ArticleStats(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewArticleRepository(db, cache.NewMockCacheService())
+
+	created, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID:      "author-1",
+		Title:         "Stats Article",
+		Body:          "# Heading\n\nFour **more** words here",
+		ContentFormat: models.ContentFormatMarkdown,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", created.ID)
+
+	if created.Excerpt != "Heading Four more words here" || created.WordCount != 5 || created.ReadingTimeMinutes != 1 {
+		t.Errorf("Unexpected stats %q, %d words, %d minutes", created.Excerpt, created.WordCount, created.ReadingTimeMinutes)
+	}
+
+	updated, err := repo.UpdateArticle(created.ID, models.UpdateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Stats Article",
+		Body:     strings.Repeat("word ", 450),
+	}, 0)
+	if err != nil {
+		t.Fatalf("Failed to update article: %v", err)
+	}
+	if updated.WordCount != 450 || updated.ReadingTimeMinutes != 3 {
+		t.Errorf("Expected the stats to be recomputed, got %d words, %d minutes", updated.WordCount, updated.ReadingTimeMinutes)
+	}
+
+	// Rows inserted directly, like the seeders do, are filled in by the backfill
+	_, err = db.Exec(`INSERT INTO articles (id, author_id, title, slug, body) VALUES ('test-backfill', 'author-1', 'Seeded', 'test-backfill', 'A seeded body')`)
+	if err != nil {
+		t.Fatalf("Failed to seed article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = 'test-backfill'")
+
+	if _, err := repo.BackfillArticleStats(10, false, nil); err != nil {
+		t.Fatalf("Failed to backfill stats: %v", err)
+	}
+	seeded, err := repo.GetArticleByID("test-backfill")
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if seeded.Excerpt != "A seeded body" || seeded.WordCount != 3 || seeded.ReadingTimeMinutes != 1 {
+		t.Errorf("Unexpected backfilled stats %q, %d words, %d minutes", seeded.Excerpt, seeded.WordCount, seeded.ReadingTimeMinutes)
+	}
+}
+
+func TestArticleRepository_RelatedArticles(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewArticleRepository(db, cache.NewMockCacheService())
+	repo.related = RelatedWeights{Tags: 1, Author: 0.5, Title: 1}
+	defer db.Exec("DELETE FROM tags WHERE name LIKE 'test-related-%'")
+
+	create := func(authorID, title string, tags ...string) *models.Article {
+		article, err := repo.CreateArticle(models.CreateArticleRequest{AuthorID: authorID, Title: title, Body: "Body", Tags: tags})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+		t.Cleanup(func() { db.Exec("DELETE FROM articles WHERE id = $1", article.ID) })
+		return article
+	}
+	source := create("author-1", "Zebra Migration Patterns", "test-related-a", "test-related-b")
+	sameTags := create("author-1", "Unrelated Heading", "test-related-a", "test-related-b")
+	similarTitle := create("author-2", "Zebra Migration Pattern")
+	draft, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1", Title: "Zebra Migration Drafts", Body: "Body", Status: models.StatusDraft, Tags: []string{"test-related-a"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", draft.ID)
+
+	related, err := repo.RelatedArticles(source.ID, 5)
+	if err != nil {
+		t.Fatalf("Failed to get related articles: %v", err)
+	}
+	if len(related) < 2 || related[0].ID != sameTags.ID {
+		t.Fatalf("Expected the article sharing tags and author first, got %+v", related)
+	}
+	foundTitle := false
+	for _, article := range related {
+		if article.ID == draft.ID || article.ID == source.ID {
+			t.Errorf("Expected only other published articles, got %s", article.ID)
+		}
+		if article.ID == similarTitle.ID {
+			foundTitle = true
+		}
+	}
+	if !foundTitle {
+		t.Errorf("Expected the article with a similar title, got %+v", related)
+	}
+
+	// Changing the source drops its cached related articles
+	if _, err := repo.UpdateArticle(source.ID, models.UpdateArticleRequest{
+		AuthorID: "author-1", Title: "Zebra Migration Patterns", Body: "Body", Tags: []string{},
+	}, 0); err != nil {
+		t.Fatalf("Failed to update article: %v", err)
+	}
+	related, err = repo.RelatedArticles(source.ID, 5)
+	if err != nil {
+		t.Fatalf("Failed to get related articles: %v", err)
+	}
+	if len(related) > 0 && related[0].ID == sameTags.ID && related[0].Score >= 1.5 {
+		t.Errorf("Expected related articles to be recomputed without tags, got %+v", related)
+	}
+
+	// Trashing a candidate drops it from the related lists it was cached in
+	if _, err := repo.RelatedArticles(source.ID, 5); err != nil {
+		t.Fatalf("Failed to get related articles: %v", err)
+	}
+	if err := repo.DeleteArticle(similarTitle.ID); err != nil {
+		t.Fatalf("Failed to delete article: %v", err)
+	}
+	related, err = repo.RelatedArticles(source.ID, 5)
+	if err != nil {
+		t.Fatalf("Failed to get related articles: %v", err)
+	}
+	for _, article := range related {
+		if article.ID == similarTitle.ID {
+			t.Errorf("Expected the trashed article to leave the cached related list, got %+v", related)
+		}
+	}
+}
+
+func TestArticleRepository_RenderArticleBody(t *testing.T) {
+	mockCache := cache.NewMockCacheService()
+	repo := NewArticleRepository(nil, mockCache)
+	article := &models.Article{ID: "test-render", Version: 1, Body: "*one*", ContentFormat: models.ContentFormatMarkdown}
+
+	rendered, err := repo.RenderArticleBody(article)
+	if err != nil {
+		t.Fatalf("Failed to render article: %v", err)
+	}
+	if rendered != "<p><em>one</em></p>\n" {
+		t.Errorf("Unexpected rendering %q", rendered)
+	}
+
+	// The rendering is cached for the version
+	article.Body = "*two*"
+	if rendered, _ := repo.RenderArticleBody(article); !strings.Contains(rendered, "one") {
+		t.Errorf("Expected the cached rendering, got %q", rendered)
+	}
+
+	article.Version++
+	if rendered, _ := repo.RenderArticleBody(article); !strings.Contains(rendered, "two") {
+		t.Errorf("Expected a new version to be rendered again, got %q", rendered)
+	}
+
+	// Each article keeps a single cached rendering, of the latest version rendered
+	var cached renderedBody
+	if err := mockCache.Get(articleHTMLCacheKey(article.ID), &cached); err != nil || cached.Version != 2 {
+		t.Errorf("Expected the cached rendering to be replaced by version 2, got %+v (%v)", cached, err)
+	}
+}
+
+func TestArticleRepository_FindDuplicateArticle(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewArticleRepository(db, cache.NewMockCacheService())
+	repo.duplicateThreshold = 0.8
+
+	existing, err := repo.CreateArticle(models.CreateArticleRequest{
+		AuthorID: "author-1",
+		Title:    "Quokka Husbandry Handbook",
+		Body:     "Quokkas need shade, fresh leaves and plenty of room to hop around.",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	defer db.Exec("DELETE FROM articles WHERE id = $1", existing.ID)
+
+	tests := []struct {
+		name  string
+		req   models.CreateArticleRequest
+		match string
+	}{
+		{"normalized title", models.CreateArticleRequest{AuthorID: "author-1", Title: " quokka  HUSBANDRY handbook", Body: "Different"}, models.DuplicateMatchTitle},
+		{"identical body", models.CreateArticleRequest{AuthorID: "author-1", Title: "Other", Body: "quokkas need shade,  fresh leaves and plenty of room to hop around."}, models.DuplicateMatchBody},
+		{"near duplicate", models.CreateArticleRequest{AuthorID: "author-1", Title: "Quokka Husbandry Handbooks", Body: "Quokkas need shade, fresh leaves and plenty of room to hop about."}, models.DuplicateMatchSimilar},
+		{"other author", models.CreateArticleRequest{AuthorID: "author-2", Title: "Quokka Husbandry Handbook", Body: existing.Body}, ""},
+		{"unrelated", models.CreateArticleRequest{AuthorID: "author-1", Title: "Wombat Burrows", Body: "Nothing alike"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duplicate, err := repo.FindDuplicateArticle(tt.req)
+			if err != nil {
+				t.Fatalf("Failed to find duplicates: %v", err)
+			}
+			if tt.match == "" {
+				if duplicate != nil {
+					t.Errorf("Expected no duplicate, got %+v", duplicate)
+				}
+				return
+			}
+			if duplicate == nil || duplicate.ID != existing.ID || duplicate.Match != tt.match {
+				t.Errorf("Expected a %s duplicate of %s, got %+v", tt.match, existing.ID, duplicate)
+			}
+		})
+	}
+}
+
+func TestArticleRepository_CreateUniqueArticle(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewArticleRepository(db, cache.NewMockCacheService())
+	defer db.Exec("DELETE FROM articles WHERE author_id = 'author-1' AND title = 'Concurrent Quokkas'")
+
+	// Concurrent creates of the same article by one author yield one article
+	req := models.CreateArticleRequest{AuthorID: "author-1", Title: "Concurrent Quokkas", Body: "Only one of these should exist."}
+	errs := make(chan error, 5)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			_, err := repo.CreateUniqueArticle(req)
+			errs <- err
+		}()
+	}
+
+	created := 0
+	for i := 0; i < cap(errs); i++ {
+		err := <-errs
+		var duplicate *DuplicateArticleError
+		switch {
+		case err == nil:
+			created++
+		case errors.As(err, &duplicate):
+			if duplicate.Duplicate.Match != models.DuplicateMatchTitle {
+				t.Errorf("Expected a title duplicate, got %+v", duplicate.Duplicate)
+			}
+		default:
+			t.Fatalf("Failed to create article: %v", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("Expected exactly one article to be created, got %d", created)
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	snippet := highlightSnippet(`use <script> with <mark>care</mark> & "quotes"`)
+
+	expected := `use &lt;script&gt; with <mark>care</mark> &amp; &#34;quotes&#34;`
+	if snippet != expected {
+		t.Errorf("Expected %q, got %q", expected, snippet)
+	}
+}
+
 func TestArticleRepository_GetAuthorByID(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
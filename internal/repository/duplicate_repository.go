@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"article-api/internal/models"
+)
+
+// normalizedText lowercases a text expression and collapses its whitespace,
+// exactly like the generated title_key and body_hash columns
+const normalizedText = `lower(regexp_replace(btrim(%s), '\s+', ' ', 'g'))`
+
+// FindDuplicateArticle returns an article by the same author that a new article
+// would duplicate, or nil when there is none. An article is a duplicate when
+// its normalized title or body is identical, or when both its title and body
+// have a trigram similarity of at least the configured threshold. Exact
+// matches win over near-duplicates. Trashed articles are ignored.
+func (r *ArticleRepository) FindDuplicateArticle(req models.CreateArticleRequest) (*models.DuplicateArticle, error) {
+	return r.findDuplicateArticle(r.db, req)
+}
+
+// findDuplicateArticle runs the duplicate check of FindDuplicateArticle on q
+func (r *ArticleRepository) findDuplicateArticle(q queryer, req models.CreateArticleRequest) (*models.DuplicateArticle, error) {
+	titleKey := fmt.Sprintf(normalizedText, "$2::text")
+	bodyHash := "md5(" + fmt.Sprintf(normalizedText, "$3::text") + ")"
+
+	query := fmt.Sprintf(`
+		SELECT id, title, slug, match_kind, similarity
+		FROM (
+			SELECT id, title, slug, created_at,
+				CASE
+					WHEN title_key = %[1]s THEN 'title'
+					WHEN body_hash = %[2]s THEN 'body'
+					ELSE 'similar'
+				END AS match_kind,
+				LEAST(similarity(title, $2), similarity(body, $3)) AS similarity
+			FROM articles
+			WHERE author_id = $1
+				AND deleted_at IS NULL
+				AND (title_key = %[1]s
+					OR body_hash = %[2]s
+					OR (similarity(title, $2) >= $4 AND similarity(body, $3) >= $4))
+		) candidates
+		ORDER BY match_kind = 'similar', similarity DESC, created_at DESC
+		LIMIT 1
+	`, titleKey, bodyHash)
+
+	var duplicate models.DuplicateArticle
+	err := q.QueryRow(query, req.AuthorID, req.Title, req.Body, r.duplicateThreshold).Scan(
+		&duplicate.ID,
+		&duplicate.Title,
+		&duplicate.Slug,
+		&duplicate.Match,
+		&duplicate.Similarity,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicate articles: %w", err)
+	}
+
+	return &duplicate, nil
+}
+
+// CreateUniqueArticle creates an article unless the author already has one it
+// duplicates, in which case it returns a *DuplicateArticleError. The check and
+// the insert share a transaction holding a per-author advisory lock, so
+// concurrent creates by the same author cannot both pass the check.
+func (r *ArticleRepository) CreateUniqueArticle(req models.CreateArticleRequest) (*models.Article, error) {
+	article, err := r.insertArticleTx(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cacheCreatedArticle(article)
+	r.invalidateListCaches()
+
+	return article, nil
+}
+
+// lockAuthors takes the advisory locks that serialize the duplicate checks of
+// each author until tx ends. They are taken in a fixed order so transactions
+// locking several authors cannot deadlock.
+func lockAuthors(tx *sql.Tx, authorIDs []string) error {
+	sorted := append([]string(nil), authorIDs...)
+	sort.Strings(sorted)
+	for i, id := range sorted {
+		if i > 0 && id == sorted[i-1] {
+			continue
+		}
+		if _, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", id); err != nil {
+			return fmt.Errorf("failed to lock the author's articles: %w", err)
+		}
+	}
+	return nil
+}
+
+// insertCheckedArticle inserts an article within tx. When unique is set it
+// returns a *DuplicateArticleError instead if the author already has an
+// article it duplicates; the caller must hold the author's lock.
+func (r *ArticleRepository) insertCheckedArticle(tx *sql.Tx, req models.CreateArticleRequest, unique bool) (*models.Article, error) {
+	if unique {
+		duplicate, err := r.findDuplicateArticle(tx, req)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate != nil {
+			return nil, &DuplicateArticleError{Duplicate: duplicate}
+		}
+	}
+	return insertArticle(tx, req)
+}
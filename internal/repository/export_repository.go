@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"article-api/internal/models"
+)
+
+// exportFetchSize is the number of rows fetched from the export cursor at a time
+const exportFetchSize = 500
+
+// ExportArticles streams every article matching the list filters to fn, in
+// the requested sort order, through a server-side cursor so the result set is
+// never held in memory. Paging, cursor and field parameters are ignored.
+// Export stops with the first error returned by fn or when ctx is cancelled.
+func (r *ArticleRepository) ExportArticles(ctx context.Context, params ListArticlesParams, fn func(*models.Article) error) error {
+	sortFields := params.Sort
+	if len(sortFields) == 0 {
+		sortFields = DefaultSort
+	}
+	if SortsByRelevance(sortFields) {
+		return fmt.Errorf("relevance sort is not supported for exports")
+	}
+
+	whereConditions, args, _ := listConditions(params)
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	// Cursors only live inside a transaction; a read-only one also gives the
+	// export a consistent snapshot
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	declare := fmt.Sprintf(`
+		DECLARE export_cursor NO SCROLL CURSOR FOR
+		SELECT %s
+		FROM articles a
+		LEFT JOIN authors au ON a.author_id = au.id
+		%s
+		ORDER BY %s
+	`, articleColumns, whereClause, orderByClause(sortKeys(sortFields, false)))
+	if _, err := tx.ExecContext(ctx, declare, args...); err != nil {
+		return fmt.Errorf("failed to open export cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM export_cursor", exportFetchSize)
+	for {
+		n, err := exportBatch(ctx, tx, fetch, fn)
+		if err != nil {
+			return err
+		}
+		if n < exportFetchSize {
+			return nil
+		}
+	}
+}
+
+// exportBatch fetches the next rows from the export cursor and hands them to
+// fn, returning how many rows were fetched
+func exportBatch(ctx context.Context, tx *sql.Tx, fetch string, fn func(*models.Article) error) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch articles: %w", err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		article, err := scanArticle(rows)
+		if err != nil {
+			return n, fmt.Errorf("failed to scan article: %w", err)
+		}
+		n++
+		if err := fn(article); err != nil {
+			return n, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return n, fmt.Errorf("error iterating articles: %w", err)
+	}
+	return n, nil
+}
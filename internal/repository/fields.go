@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"article-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// listField is a selectable field of an article listing: the SQL expression
+// that produces it and where a scanned row stores it
+type listField struct {
+	column string
+	dest   func(article *models.ArticleListItem) interface{}
+	// search fields are computed from the full-text query, which the column
+	// receives as its %[1]s verb
+	search bool
+}
+
+// listAuthor returns the author of a listed article, allocating it on first use
+func listAuthor(article *models.ArticleListItem) *models.Author {
+	if article.Author == nil {
+		article.Author = &models.Author{}
+	}
+	return article.Author
+}
+
+// listFields maps the field names accepted by ?fields= to their columns
+var listFields = map[string]listField{
+	"id":                   {column: "a.id", dest: func(a *models.ArticleListItem) interface{} { return &a.ID }},
+	"author_id":            {column: "a.author_id", dest: func(a *models.ArticleListItem) interface{} { return &a.AuthorID }},
+	"title":                {column: "a.title", dest: func(a *models.ArticleListItem) interface{} { return &a.Title }},
+	"slug":                 {column: "a.slug", dest: func(a *models.ArticleListItem) interface{} { return &a.Slug }},
+	"body":                 {column: "a.body", dest: func(a *models.ArticleListItem) interface{} { return &a.Body }},
+	"content_format":       {column: "a.content_format", dest: func(a *models.ArticleListItem) interface{} { return &a.ContentFormat }},
+	"excerpt":              {column: "a.excerpt", dest: func(a *models.ArticleListItem) interface{} { return &a.Excerpt }},
+	"word_count":           {column: "a.word_count", dest: func(a *models.ArticleListItem) interface{} { return &a.WordCount }},
+	"reading_time_minutes": {column: "a.reading_time_minutes", dest: func(a *models.ArticleListItem) interface{} { return &a.ReadingTimeMinutes }},
+	"created_at":           {column: "a.created_at", dest: func(a *models.ArticleListItem) interface{} { return &a.CreatedAt }},
+	"updated_at":           {column: "a.updated_at", dest: func(a *models.ArticleListItem) interface{} { return &a.UpdatedAt }},
+	"version":              {column: "a.version", dest: func(a *models.ArticleListItem) interface{} { return &a.Version }},
+	"status":               {column: "a.status", dest: func(a *models.ArticleListItem) interface{} { return &a.Status }},
+	"published_at":         {column: "a.published_at", dest: func(a *models.ArticleListItem) interface{} { return &a.PublishedAt }},
+	"deleted_at":           {column: "a.deleted_at", dest: func(a *models.ArticleListItem) interface{} { return &a.DeletedAt }},
+	"tags":                 {column: articleTagsColumn, dest: func(a *models.ArticleListItem) interface{} { return pq.Array(&a.Tags) }},
+	"author.id":            {column: "au.id", dest: func(a *models.ArticleListItem) interface{} { return &listAuthor(a).ID }},
+	"author.name":          {column: "au.name", dest: func(a *models.ArticleListItem) interface{} { return &listAuthor(a).Name }},
+	"snippet": {
+		column: "ts_headline('english', a.body, %[1]s, '" + headlineOptions + "') as snippet",
+		dest:   func(a *models.ArticleListItem) interface{} { return &a.Snippet },
+		search: true,
+	},
+	"rank": {
+		column: "ts_rank(a.search_vector, %[1]s) as rank",
+		dest:   func(a *models.ArticleListItem) interface{} { return &a.Rank },
+		search: true,
+	},
+}
+
+// DefaultListFields are returned when a listing does not ask for specific
+// fields; the article body is left out for performance
+var DefaultListFields = []string{
+	"id", "author_id", "title", "slug", "excerpt", "word_count", "reading_time_minutes",
+	"created_at", "status", "published_at", "deleted_at", "tags", "author.id", "author.name",
+	"snippet", "rank",
+}
+
+// sortListFields names the list field holding each sort key, which must be
+// fetched even when not requested so cursors can be built
+var sortListFields = map[string]string{
+	"created_at":  "created_at",
+	"title":       "title",
+	"author":      "author.name",
+	SortRelevance: "rank",
+}
+
+// ParseListFields parses a comma-separated field list such as "id,title,author.name".
+// "author" selects both author fields.
+func ParseListFields(expr string) ([]string, error) {
+	var fields []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+
+		names := []string{part}
+		if part == "author" {
+			names = []string{"author.id", "author.name"}
+		} else if _, ok := listFields[part]; !ok {
+			return nil, fmt.Errorf("unknown field %q", part)
+		}
+
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				fields = append(fields, name)
+			}
+		}
+	}
+	return fields, nil
+}
+
+// SearchListFields reports which of the fields can only be computed for a search
+func SearchListFields(fields []string) []string {
+	var search []string
+	for _, field := range fields {
+		if listFields[field].search {
+			search = append(search, field)
+		}
+	}
+	return search
+}
+
+// selectedListFields returns the requested fields plus the id and sort keys the
+// listing needs internally. Search fields are dropped when there is no search.
+func selectedListFields(requested []string, sort []SortField, searching bool) []string {
+	var fields []string
+	seen := map[string]bool{}
+	add := func(field string) {
+		if !seen[field] && (searching || !listFields[field].search) {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+
+	add("id")
+	for _, field := range requested {
+		add(field)
+	}
+	for _, field := range sort {
+		add(sortListFields[field.Field])
+	}
+	return fields
+}
+
+// listColumns renders the SELECT list for fields, passing searchQuery to search columns
+func listColumns(fields []string, searchQuery string) string {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = listFields[field].column
+		if listFields[field].search {
+			columns[i] = fmt.Sprintf(columns[i], searchQuery)
+		}
+	}
+	return strings.Join(columns, ",\n\t\t\t")
+}
+
+// listDest returns scan destinations for fields within article
+func listDest(fields []string, article *models.ArticleListItem) []interface{} {
+	dest := make([]interface{}, len(fields))
+	for i, field := range fields {
+		dest[i] = listFields[field].dest(article)
+	}
+	return dest
+}
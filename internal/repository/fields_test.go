@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseListFields(t *testing.T) {
+	fields, err := ParseListFields("title, author,id,title")
+	if err != nil {
+		t.Fatalf("Failed to parse fields: %v", err)
+	}
+	expected := []string{"title", "author.id", "author.name", "id"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+
+	for _, expr := range []string{"password", "id,", "author.email"} {
+		if _, err := ParseListFields(expr); err == nil {
+			t.Errorf("Expected an error for fields %q", expr)
+		}
+	}
+}
+
+func TestSelectedListFields(t *testing.T) {
+	sort := []SortField{{Field: "author"}, {Field: "created_at", Desc: true}}
+
+	// The id and sort keys are always fetched; search fields need a search
+	fields := selectedListFields([]string{"title", "snippet"}, sort, false)
+	expected := []string{"id", "title", "author.name", "created_at"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+
+	columns := listColumns([]string{"id", "rank"}, "websearch_to_tsquery('english', $1)")
+	if columns != "a.id,\n\t\t\tts_rank(a.search_vector, websearch_to_tsquery('english', $1)) as rank" {
+		t.Errorf("Unexpected columns %q", columns)
+	}
+}
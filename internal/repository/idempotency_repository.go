@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"article-api/internal/cache"
+	"article-api/internal/models"
+)
+
+// IdempotencyTTL is how long the response to an idempotent request is kept
+const IdempotencyTTL = 24 * time.Hour
+
+// IdempotencyLockTTL is how long a key stays reserved by a request that never
+// stores its response, e.g. because its process died
+const IdempotencyLockTTL = 5 * time.Minute
+
+// idempotencyCacheKey returns the cache key of an idempotency record
+func idempotencyCacheKey(key string) string {
+	return fmt.Sprintf("idempotency:%s", key)
+}
+
+// CacheIdempotencyStore keeps idempotency records in the cache, which expires them
+type CacheIdempotencyStore struct {
+	cache cache.CacheServiceInterface
+}
+
+// NewCacheIdempotencyStore creates an idempotency store backed by the cache
+func NewCacheIdempotencyStore(cacheService cache.CacheServiceInterface) *CacheIdempotencyStore {
+	return &CacheIdempotencyStore{cache: cacheService}
+}
+
+// ReserveIdempotencyKey stores a pending record for key unless one exists,
+// reporting whether the key was reserved
+func (s *CacheIdempotencyStore) ReserveIdempotencyKey(key, fingerprint string) (bool, error) {
+	pending := models.IdempotencyRecord{Fingerprint: fingerprint, CreatedAt: time.Now()}
+	reserved, err := s.cache.SetNX(idempotencyCacheKey(key), pending, int(IdempotencyLockTTL.Seconds()))
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	return reserved, nil
+}
+
+// GetIdempotencyRecord returns the record stored for key
+func (s *CacheIdempotencyStore) GetIdempotencyRecord(key string) (*models.IdempotencyRecord, error) {
+	var record models.IdempotencyRecord
+	if err := s.cache.Get(idempotencyCacheKey(key), &record); err != nil {
+		return nil, &IdempotencyKeyNotFoundError{}
+	}
+	return &record, nil
+}
+
+// SaveIdempotencyRecord stores the record for key for IdempotencyTTL
+func (s *CacheIdempotencyStore) SaveIdempotencyRecord(key string, record models.IdempotencyRecord) error {
+	if err := s.cache.SetWithTTL(idempotencyCacheKey(key), record, int(IdempotencyTTL.Seconds())); err != nil {
+		return fmt.Errorf("failed to cache idempotency record: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey drops the reservation of key so the request can be retried
+func (s *CacheIdempotencyStore) ReleaseIdempotencyKey(key string) error {
+	if err := s.cache.Delete(idempotencyCacheKey(key)); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// IdempotencyRepository keeps idempotency records in PostgreSQL, for
+// deployments running without Redis
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates an idempotency store backed by the database
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// GetIdempotencyRecord returns the unexpired record stored for key
+func (r *IdempotencyRepository) GetIdempotencyRecord(key string) (*models.IdempotencyRecord, error) {
+	query := `
+		SELECT fingerprint, status, header, body, created_at
+		FROM idempotency_keys
+		WHERE key = $1 AND created_at > $2
+	`
+
+	var record models.IdempotencyRecord
+	var header []byte
+	err := r.db.QueryRow(query, key, time.Now().Add(-IdempotencyTTL)).
+		Scan(&record.Fingerprint, &record.Status, &header, &record.Body, &record.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, &IdempotencyKeyNotFoundError{}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency record: %w", err)
+	}
+	if err := json.Unmarshal(header, &record.Header); err != nil {
+		return nil, fmt.Errorf("invalid idempotency record header: %w", err)
+	}
+	return &record, nil
+}
+
+// ReserveIdempotencyKey inserts a pending record for key unless an unexpired
+// one exists, reporting whether the key was reserved. Expired records and
+// abandoned reservations are dropped first.
+func (r *IdempotencyRepository) ReserveIdempotencyKey(key, fingerprint string) (bool, error) {
+	now := time.Now()
+	purge := `DELETE FROM idempotency_keys WHERE created_at <= $1 OR (status = 0 AND created_at <= $2)`
+	if _, err := r.db.Exec(purge, now.Add(-IdempotencyTTL), now.Add(-IdempotencyLockTTL)); err != nil {
+		return false, fmt.Errorf("failed to purge idempotency records: %w", err)
+	}
+
+	query := `
+		INSERT INTO idempotency_keys (key, fingerprint, status, body, created_at)
+		VALUES ($1, $2, 0, '', $3)
+		ON CONFLICT (key) DO NOTHING
+		RETURNING key
+	`
+	var reserved string
+	err := r.db.QueryRow(query, key, fingerprint, now).Scan(&reserved)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	return true, nil
+}
+
+// SaveIdempotencyRecord stores the record for key, replacing its reservation
+func (r *IdempotencyRepository) SaveIdempotencyRecord(key string, record models.IdempotencyRecord) error {
+	header, err := json.Marshal(record.Header)
+	if err != nil {
+		return fmt.Errorf("failed to encode idempotency record header: %w", err)
+	}
+
+	query := `
+		INSERT INTO idempotency_keys (key, fingerprint, status, header, body, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status = EXCLUDED.status,
+			header = EXCLUDED.header,
+			body = EXCLUDED.body,
+			created_at = EXCLUDED.created_at
+	`
+	if _, err := r.db.Exec(query, key, record.Fingerprint, record.Status, header, record.Body, record.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save idempotency record: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey drops the reservation of key so the request can be retried
+func (r *IdempotencyRepository) ReleaseIdempotencyKey(key string) error {
+	if _, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE key = $1 AND status = 0`, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
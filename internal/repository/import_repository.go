@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"article-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// importColumns is the number of bind parameters per imported article row
+const importColumns = 12
+
+// MaxImportBatch keeps a multi-row INSERT within PostgreSQL's 65535 bind parameters
+const MaxImportBatch = 65535 / importColumns
+
+// ImportArticles inserts validated articles with one multi-row INSERT in a single
+// transaction and returns their IDs in order. Slugs are made unique against the
+// database and each other with one query for the whole batch. Either every
+// article is inserted or none is.
+func (r *ArticleRepository) ImportArticles(reqs []models.CreateArticleRequest) ([]string, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	if len(reqs) > MaxImportBatch {
+		return nil, fmt.Errorf("import batch of %d articles exceeds %d", len(reqs), MaxImportBatch)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	titles := make([]string, len(reqs))
+	for i, req := range reqs {
+		titles[i] = req.Title
+	}
+	slugs, err := uniqueSlugs(tx, titles)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ids := make([]string, len(reqs))
+	values := make([]string, len(reqs))
+	args := make([]interface{}, 0, len(reqs)*importColumns)
+	var tagArticleIDs, tagNames []string
+	for i, req := range reqs {
+		ids[i] = nextArticleID()
+		status, publishedAt := initialPublication(req.Status, req.PublishedAt, now)
+
+		format := contentFormat(req.ContentFormat)
+		stats, err := articleStats(req.Body, format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import articles: %w", err)
+		}
+
+		n := i * importColumns
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10, n+10, n+11, n+12)
+		args = append(args, ids[i], req.AuthorID, req.Title, slugs[i], req.Body, format,
+			stats.Excerpt, stats.WordCount, stats.ReadingTimeMinutes, now, status, publishedAt)
+
+		for _, tag := range req.Tags {
+			tagArticleIDs = append(tagArticleIDs, ids[i])
+			tagNames = append(tagNames, tag)
+		}
+	}
+
+	query := `
+		INSERT INTO articles (id, author_id, title, slug, body, content_format, excerpt, word_count, reading_time_minutes, created_at, updated_at, status, published_at)
+		VALUES ` + strings.Join(values, ",\n\t\t\t")
+	if _, err := tx.Exec(query, args...); err != nil {
+		return nil, fmt.Errorf("failed to import articles: %w", err)
+	}
+
+	if len(tagNames) > 0 {
+		_, err := tx.Exec(`INSERT INTO tags (name) SELECT DISTINCT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`, pq.Array(tagNames))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tags: %w", err)
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO article_tags (article_id, tag_id)
+			SELECT x.article_id, t.id
+			FROM unnest($1::text[], $2::text[]) AS x(article_id, name)
+			JOIN tags t ON t.name = x.name
+		`, pq.Array(tagArticleIDs), pq.Array(tagNames))
+		if err != nil {
+			return nil, fmt.Errorf("failed to tag articles: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	r.invalidateListCaches()
+
+	return ids, nil
+}
+
+// uniqueSlugs derives a slug for each new article from its title, avoiding
+// slugs in use or in the slug history as well as each other
+func uniqueSlugs(q queryer, titles []string) ([]string, error) {
+	bases := make([]string, len(titles))
+	need := map[string]int{}
+	var distinct []string
+	for i, title := range titles {
+		bases[i] = slugBase(title)
+		if need[bases[i]] == 0 {
+			distinct = append(distinct, bases[i])
+		}
+		need[bases[i]]++
+	}
+
+	// Check candidates for every base in one query, trying further ones for
+	// the bases that are still short of free slugs
+	free := map[string][]string{}
+	picked := map[string]bool{}
+	next := map[string]int{}
+	for {
+		var candidates, candidateBases []string
+		for _, base := range distinct {
+			missing := need[base] - len(free[base])
+			for i := 0; missing > 0 && i < missing+slugBatch; i++ {
+				next[base]++
+				candidates = append(candidates, slugCandidate(base, next[base]))
+				candidateBases = append(candidateBases, base)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		taken, err := takenSlugs(q, candidates, "")
+		if err != nil {
+			return nil, err
+		}
+		for i, candidate := range candidates {
+			base := candidateBases[i]
+			if !taken[candidate] && !picked[candidate] && len(free[base]) < need[base] {
+				picked[candidate] = true
+				free[base] = append(free[base], candidate)
+			}
+		}
+	}
+
+	slugs := make([]string, len(titles))
+	used := map[string]int{}
+	for i, base := range bases {
+		slugs[i] = free[base][used[base]]
+		used[base]++
+	}
+	return slugs, nil
+}
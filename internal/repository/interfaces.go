@@ -1,20 +1,95 @@
 package repository
 
-import "article-api/internal/models"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"article-api/internal/cursor"
+	"article-api/internal/models"
+)
 
 // ArticleRepositoryInterface defines the contract for article repository operations
 type ArticleRepositoryInterface interface {
 	ListArticles(params ListArticlesParams) (*ListArticlesResult, error)
+	ExportArticles(ctx context.Context, params ListArticlesParams, fn func(*models.Article) error) error
 	CreateArticle(req models.CreateArticleRequest) (*models.Article, error)
+	CreateUniqueArticle(req models.CreateArticleRequest) (*models.Article, error)
+	CreateArticles(reqs []models.CreateArticleRequest, atomic, unique bool) ([]*models.Article, []error, error)
+	GetArticleByID(id string) (*models.Article, error)
+	GetArticleBySlug(slug string) (*models.Article, error)
+	RenderArticleBody(article *models.Article) (string, error)
+	RelatedArticles(id string, limit int) ([]models.RelatedArticle, error)
+	UpdateArticle(id string, req models.UpdateArticleRequest, expectedVersion int) (*models.Article, error)
+	DeleteArticle(id string) error
+	RestoreArticle(id string) (*models.Article, error)
+	ListRevisions(articleID string) ([]models.ArticleRevision, error)
+	GetRevision(articleID string, revision int) (*models.ArticleRevision, error)
 	GetAuthorByID(id string) (*models.Author, error)
+	GetAuthorsByIDs(ids []string) (map[string]*models.Author, error)
+}
+
+// TagRepositoryInterface defines the contract for tag repository operations
+type TagRepositoryInterface interface {
+	ListTags(limit int) ([]models.Tag, error)
+	RenameTag(name, newName string) (*models.Tag, error)
+	MergeTags(sources []string, target string) (*models.Tag, error)
+}
+
+// SitemapRepositoryInterface defines the contract for sitemap queries
+type SitemapRepositoryInterface interface {
+	SitemapPages(size int) ([]time.Time, error)
+	SitemapEntries(page, size int) ([]models.SitemapEntry, error)
 }
 
+// IdempotencyStoreInterface defines the contract for storing the responses to
+// requests sent with an Idempotency-Key. A key is reserved atomically before
+// its request runs, so only one request per key is ever processed.
+type IdempotencyStoreInterface interface {
+	ReserveIdempotencyKey(key, fingerprint string) (bool, error)
+	GetIdempotencyRecord(key string) (*models.IdempotencyRecord, error)
+	SaveIdempotencyRecord(key string, record models.IdempotencyRecord) error
+	ReleaseIdempotencyKey(key string) error
+}
+
+// SortRelevance orders search results by full-text rank
+const SortRelevance = "relevance"
+
 // ListArticlesParams holds parameters for listing articles
 type ListArticlesParams struct {
-	Search     string
+	// Search is a full-text query in websearch syntax ("quoted phrases", -excluded, or)
+	Search string
+	// AuthorName matches author names case-insensitively by substring
 	AuthorName string
-	Page       int
-	Limit      int
+	// AuthorIDs keeps only articles by these authors; ExcludeAuthorIDs drops them
+	AuthorIDs        []string
+	ExcludeAuthorIDs []string
+	// CreatedAfter and CreatedBefore bound the creation time, exclusively
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Page          int
+	Limit         int
+	// Cursor switches to keyset pagination from a boundary row; Page is
+	// ignored and the cursor must have been issued for the same Sort
+	Cursor *cursor.Cursor
+	// Fields selects the columns to fetch, DefaultListFields when empty; the
+	// id and sort keys are always fetched as well
+	Fields []string
+	// Sort selects the result order, DefaultSort when empty; relevance
+	// requires Search and offset pagination. Ties are broken by id.
+	Sort []SortField
+	// IncludeDeleted also returns soft-deleted articles
+	IncludeDeleted bool
+	// OnlyDeleted returns soft-deleted articles only (the trash)
+	OnlyDeleted bool
+	// Tag, TagsAny and TagsAll filter by normalized tag names
+	Tag     string
+	TagsAny []string
+	TagsAll []string
+	// Status filters by lifecycle status; only published articles are public
+	Status string
+	// AllStatuses disables status visibility rules (admin views)
+	AllStatuses bool
 }
 
 // ListArticlesResult holds the result of listing articles
@@ -23,6 +98,10 @@ type ListArticlesResult struct {
 	Total    int
 	Page     int
 	Limit    int
+	// NextCursor and PrevCursor continue the listing from the last and
+	// first returned article; nil when there is nothing further that way
+	NextCursor *cursor.Cursor
+	PrevCursor *cursor.Cursor
 }
 
 // AuthorNotFoundError represents an error when author is not found
@@ -31,3 +110,73 @@ type AuthorNotFoundError struct{}
 func (e *AuthorNotFoundError) Error() string {
 	return "author not found"
 }
+
+// BatchAbortedError marks a batch item that was rolled back because another
+// item of the same atomic batch failed
+type BatchAbortedError struct {
+	Index int
+}
+
+func (e *BatchAbortedError) Error() string {
+	return fmt.Sprintf("rolled back because item %d failed", e.Index)
+}
+
+// DuplicateArticleError represents an error when a new article duplicates an
+// existing article by the same author
+type DuplicateArticleError struct {
+	Duplicate *models.DuplicateArticle
+}
+
+func (e *DuplicateArticleError) Error() string {
+	return fmt.Sprintf("duplicate of article %s", e.Duplicate.ID)
+}
+
+// ArticleNotFoundError represents an error when article is not found
+type ArticleNotFoundError struct{}
+
+func (e *ArticleNotFoundError) Error() string {
+	return "article not found"
+}
+
+// VersionConflictError represents an error when an update targets a stale article version
+type VersionConflictError struct {
+	CurrentVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("article version conflict: current version is %d", e.CurrentVersion)
+}
+
+// RevisionNotFoundError represents an error when an article revision is not found
+type RevisionNotFoundError struct {
+	Revision int
+}
+
+func (e *RevisionNotFoundError) Error() string {
+	return fmt.Sprintf("revision %d not found", e.Revision)
+}
+
+// TagNotFoundError represents an error when a tag is not found
+type TagNotFoundError struct {
+	Name string
+}
+
+func (e *TagNotFoundError) Error() string {
+	return fmt.Sprintf("tag %s not found", e.Name)
+}
+
+// TagExistsError represents an error when renaming a tag onto an existing tag
+type TagExistsError struct {
+	Name string
+}
+
+func (e *TagExistsError) Error() string {
+	return fmt.Sprintf("tag %s already exists", e.Name)
+}
+
+// IdempotencyKeyNotFoundError represents an error when no response is stored for an Idempotency-Key
+type IdempotencyKeyNotFoundError struct{}
+
+func (e *IdempotencyKeyNotFoundError) Error() string {
+	return "idempotency key not found"
+}
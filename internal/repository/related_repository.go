@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"fmt"
+
+	"article-api/internal/models"
+)
+
+// MaxRelatedLimit bounds the number of related articles returned at once
+const MaxRelatedLimit = 20
+
+// RelatedWeights weighs the signals related articles are ranked by. Each
+// signal scores between 0 and 1 before weighting.
+type RelatedWeights struct {
+	// Tags weighs the Jaccard similarity of the two articles' tag sets
+	Tags float64
+	// Author weighs sharing the same author
+	Author float64
+	// Title weighs the pg_trgm similarity of the titles
+	Title float64
+}
+
+// relatedFields are the list fields returned for a related article
+var relatedFields = []string{
+	"id", "author_id", "title", "slug", "excerpt", "word_count", "reading_time_minutes",
+	"created_at", "status", "published_at", "tags", "author.id", "author.name",
+}
+
+// relatedCacheKey returns the cache key of an article's related list. Related
+// lists live under the listing prefix: any article can show up in another's
+// list, so every change that invalidates the listings drops them too.
+func relatedCacheKey(id string, limit int) string {
+	return fmt.Sprintf("%s:related:%s:%d", listCachePrefix, id, limit)
+}
+
+// RelatedArticles returns up to limit published articles most similar to the
+// article, best first. Candidates share a tag or the author with it or have a
+// title similar enough for the trigram index; they are ranked by the weighted
+// sum of the three signals. Results are cached until any article changes or
+// the listing TTL runs out.
+func (r *ArticleRepository) RelatedArticles(id string, limit int) ([]models.RelatedArticle, error) {
+	if limit < 1 || limit > MaxRelatedLimit {
+		limit = MaxRelatedLimit
+	}
+	cacheKey := relatedCacheKey(id, limit)
+
+	var cached []models.RelatedArticle
+	if err := r.cache.Get(cacheKey, &cached); err == nil {
+		return cached, nil
+	}
+
+	query := fmt.Sprintf(`
+		WITH source AS (
+			SELECT id, author_id, title,
+				ARRAY(SELECT tag_id FROM article_tags WHERE article_id = $1) AS tag_ids
+			FROM articles
+			WHERE id = $1 AND deleted_at IS NULL
+		),
+		scored AS (
+			SELECT c.id,
+				$2::float8 * COALESCE(t.shared::float8 / NULLIF(cardinality(s.tag_ids) + t.total - t.shared, 0), 0)
+				+ $3::float8 * (c.author_id = s.author_id)::int
+				+ $4::float8 * similarity(c.title, s.title) AS score
+			FROM source s
+			JOIN articles c ON c.id <> s.id
+			CROSS JOIN LATERAL (
+				SELECT COUNT(*) FILTER (WHERE at.tag_id = ANY(s.tag_ids)) AS shared, COUNT(*) AS total
+				FROM article_tags at
+				WHERE at.article_id = c.id
+			) t
+			WHERE c.deleted_at IS NULL
+				AND c.status = 'published'
+				AND (c.author_id = s.author_id
+					OR c.title %% s.title
+					OR EXISTS (SELECT 1 FROM article_tags at WHERE at.article_id = c.id AND at.tag_id = ANY(s.tag_ids)))
+		)
+		SELECT
+			%s,
+			sc.score
+		FROM scored sc
+		JOIN articles a ON a.id = sc.id
+		LEFT JOIN authors au ON a.author_id = au.id
+		WHERE sc.score > 0
+		ORDER BY sc.score DESC, a.created_at DESC, a.id
+		LIMIT $5
+	`, listColumns(relatedFields, ""))
+
+	rows, err := r.db.Query(query, id, r.related.Tags, r.related.Author, r.related.Title, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query related articles: %w", err)
+	}
+	defer rows.Close()
+
+	related := []models.RelatedArticle{}
+	for rows.Next() {
+		var article models.RelatedArticle
+		dest := append(listDest(relatedFields, &article.ArticleListItem), &article.Score)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan related article: %w", err)
+		}
+		related = append(related, article)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read related articles: %w", err)
+	}
+
+	if cacheErr := r.cache.SetWithTTL(cacheKey, related, r.listTTL); cacheErr != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Failed to cache related articles: %v\n", cacheErr)
+	}
+
+	return related, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"article-api/internal/models"
+)
+
+// ListRevisions retrieves the revision history of an article, newest first
+func (r *ArticleRepository) ListRevisions(articleID string) ([]models.ArticleRevision, error) {
+	if err := r.ensureArticleExists(articleID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT article_id, revision, author_id, title, body, edited_by, created_at
+		FROM article_revisions
+		WHERE article_id = $1
+		ORDER BY revision DESC
+	`
+
+	rows, err := r.db.Query(query, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []models.ArticleRevision{}
+	for rows.Next() {
+		var revision models.ArticleRevision
+		err := rows.Scan(
+			&revision.ArticleID,
+			&revision.Revision,
+			&revision.AuthorID,
+			&revision.Title,
+			&revision.Body,
+			&revision.EditedBy,
+			&revision.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetRevision retrieves a single revision of an article
+func (r *ArticleRepository) GetRevision(articleID string, revision int) (*models.ArticleRevision, error) {
+	if err := r.ensureArticleExists(articleID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT article_id, revision, author_id, title, body, edited_by, created_at
+		FROM article_revisions
+		WHERE article_id = $1 AND revision = $2
+	`
+
+	var rev models.ArticleRevision
+	err := r.db.QueryRow(query, articleID, revision).Scan(
+		&rev.ArticleID,
+		&rev.Revision,
+		&rev.AuthorID,
+		&rev.Title,
+		&rev.Body,
+		&rev.EditedBy,
+		&rev.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &RevisionNotFoundError{Revision: revision}
+		}
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	return &rev, nil
+}
+
+// ensureArticleExists returns ArticleNotFoundError unless the article exists and is not trashed
+func (r *ArticleRepository) ensureArticleExists(id string) error {
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM articles WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check article: %w", err)
+	}
+	if !exists {
+		return &ArticleNotFoundError{}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"article-api/internal/models"
+)
+
+// sitemapCondition selects the publicly visible articles a sitemap lists
+const sitemapCondition = "a.status = 'published' AND a.deleted_at IS NULL"
+
+// sitemapOrder keeps sitemap pages stable as articles are published
+const sitemapOrder = "a.created_at, a.id"
+
+// SitemapCacheKey returns the cache key of a rendered sitemap. Sitemaps live
+// under the listing prefix, so every article change invalidates them.
+func SitemapCacheKey(name string) string {
+	return fmt.Sprintf("%s:sitemap:%s", listCachePrefix, name)
+}
+
+// SitemapPages splits the published articles into pages of size entries and
+// returns the latest modification time of each page, oldest articles first
+func (r *ArticleRepository) SitemapPages(size int) ([]time.Time, error) {
+	query := fmt.Sprintf(`
+		SELECT MAX(lastmod)
+		FROM (
+			SELECT
+				COALESCE(a.updated_at, a.created_at) AS lastmod,
+				(ROW_NUMBER() OVER (ORDER BY %s) - 1) / $1 AS page
+			FROM articles a
+			WHERE %s
+		) entries
+		GROUP BY page
+		ORDER BY page
+	`, sitemapOrder, sitemapCondition)
+
+	rows, err := r.db.Query(query, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sitemap pages: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []time.Time
+	for rows.Next() {
+		var lastMod time.Time
+		if err := rows.Scan(&lastMod); err != nil {
+			return nil, fmt.Errorf("failed to scan sitemap page: %w", err)
+		}
+		pages = append(pages, lastMod)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sitemap pages: %w", err)
+	}
+	return pages, nil
+}
+
+// SitemapEntries returns one page of size published articles, numbered from 1
+func (r *ArticleRepository) SitemapEntries(page, size int) ([]models.SitemapEntry, error) {
+	query := fmt.Sprintf(`
+		SELECT a.slug, COALESCE(a.updated_at, a.created_at)
+		FROM articles a
+		WHERE %s
+		ORDER BY %s
+		LIMIT $1 OFFSET $2
+	`, sitemapCondition, sitemapOrder)
+
+	rows, err := r.db.Query(query, size, (page-1)*size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sitemap entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.SitemapEntry
+	for rows.Next() {
+		var entry models.SitemapEntry
+		if err := rows.Scan(&entry.Slug, &entry.LastMod); err != nil {
+			return nil, fmt.Errorf("failed to scan sitemap entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sitemap entries: %w", err)
+	}
+	return entries, nil
+}
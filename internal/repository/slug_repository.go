@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"article-api/internal/models"
+	"article-api/internal/slug"
+
+	"github.com/lib/pq"
+)
+
+// queryer is satisfied by *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// slugBatch is the number of candidate slugs checked per query
+const slugBatch = 20
+
+// maxSlugAttempts bounds how often an insert retries with a new slug after a
+// concurrent insert took the one it picked
+const maxSlugAttempts = 3
+
+// slugCandidate returns the nth slug tried for base: base itself, then base-2,
+// base-3 and so on, shortened to fit like slug.WithSuffix does
+func slugCandidate(base string, n int) string {
+	if n == 1 {
+		return base
+	}
+	return slug.WithSuffix(base, n)
+}
+
+// slugBase derives the base slug of a title
+func slugBase(title string) string {
+	if base := slug.Make(title); base != "" {
+		return base
+	}
+	return "article"
+}
+
+// takenSlugs returns which of the candidates another article than articleID
+// currently uses or used before. Slugs are matched exactly, since suffixed
+// candidates of long titles are shortened and no longer share a prefix.
+func takenSlugs(q queryer, candidates []string, articleID string) (map[string]bool, error) {
+	query := `
+		SELECT slug FROM articles WHERE slug = ANY($1) AND id <> $2
+		UNION
+		SELECT slug FROM article_slug_history WHERE slug = ANY($1) AND article_id <> $2
+	`
+	rows, err := q.Query(query, pq.Array(candidates), articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slug availability: %w", err)
+	}
+	defer rows.Close()
+
+	taken := map[string]bool{}
+	for rows.Next() {
+		var existing string
+		if err := rows.Scan(&existing); err != nil {
+			return nil, fmt.Errorf("failed to scan slug: %w", err)
+		}
+		taken[existing] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating slugs: %w", err)
+	}
+	return taken, nil
+}
+
+// uniqueSlug derives a slug from title that no other article currently uses or
+// used before. Slugs previously held by articleID itself are available again.
+func uniqueSlug(q queryer, title, articleID string) (string, error) {
+	base := slugBase(title)
+	for first := 1; ; first += slugBatch {
+		candidates := make([]string, slugBatch)
+		for i := range candidates {
+			candidates[i] = slugCandidate(base, first+i)
+		}
+
+		taken, err := takenSlugs(q, candidates, articleID)
+		if err != nil {
+			return "", err
+		}
+		for _, candidate := range candidates {
+			if !taken[candidate] {
+				return candidate, nil
+			}
+		}
+	}
+}
+
+// retrySlugConflicts runs attempt within tx and runs it again, up to
+// maxSlugAttempts times, when a concurrent insert took the slug it picked.
+// Each attempt runs in a savepoint so a conflict does not abort tx.
+func retrySlugConflicts(tx *sql.Tx, attempt func() error) error {
+	for n := 1; ; n++ {
+		if _, err := tx.Exec(`SAVEPOINT article_slug`); err != nil {
+			return fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		err := attempt()
+		if err == nil {
+			if _, err := tx.Exec(`RELEASE SAVEPOINT article_slug`); err != nil {
+				return fmt.Errorf("failed to release savepoint: %w", err)
+			}
+			return nil
+		}
+		if !isSlugConflict(err) || n == maxSlugAttempts {
+			return err
+		}
+
+		if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT article_slug`); err != nil {
+			return fmt.Errorf("failed to roll back to savepoint: %w", err)
+		}
+	}
+}
+
+// isSlugConflict reports whether err is a concurrent insert taking the same slug
+func isSlugConflict(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "idx_articles_slug"
+}
+
+// recordSlugChange keeps the previous slug of an article resolvable after it changes
+func recordSlugChange(q queryer, articleID, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	_, err := q.Exec(`
+		INSERT INTO article_slug_history (slug, article_id) VALUES ($1, $2)
+		ON CONFLICT (slug) DO UPDATE SET article_id = EXCLUDED.article_id, created_at = CURRENT_TIMESTAMP
+	`, oldSlug, articleID)
+	if err != nil {
+		return fmt.Errorf("failed to record slug history: %w", err)
+	}
+
+	// The article may have returned to one of its own previous slugs
+	if _, err := q.Exec(`DELETE FROM article_slug_history WHERE slug = $1`, newSlug); err != nil {
+		return fmt.Errorf("failed to update slug history: %w", err)
+	}
+	return nil
+}
+
+// GetArticleBySlug retrieves an article by its current or a previous slug.
+// Callers compare the returned article's Slug with the requested one to
+// detect historical slugs.
+func (r *ArticleRepository) GetArticleBySlug(articleSlug string) (*models.Article, error) {
+	query := `
+		SELECT id FROM articles WHERE slug = $1 AND deleted_at IS NULL
+		UNION ALL
+		SELECT h.article_id FROM article_slug_history h
+		JOIN articles a ON a.id = h.article_id AND a.deleted_at IS NULL
+		WHERE h.slug = $1
+		LIMIT 1
+	`
+
+	var id string
+	if err := r.db.QueryRow(query, articleSlug).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &ArticleNotFoundError{}
+		}
+		return nil, fmt.Errorf("failed to resolve slug: %w", err)
+	}
+
+	return r.GetArticleByID(id)
+}
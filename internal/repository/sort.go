@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"article-api/internal/models"
+)
+
+// SortField is one key of an article list ordering
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// sortColumns maps the sortable fields to their SQL expressions; queries alias
+// the article row as "a", the author row as "au" and the search rank as "rank"
+var sortColumns = map[string]string{
+	"created_at":  "a.created_at",
+	"title":       "a.title",
+	"author":      "au.name",
+	SortRelevance: "rank",
+}
+
+// DefaultSort lists the newest articles first
+var DefaultSort = []SortField{{Field: "created_at", Desc: true}}
+
+// ParseSort parses a comma-separated sort expression such as "author,-created_at".
+// A leading "-" sorts a field descending; relevance always ranks best matches first.
+func ParseSort(expr string) ([]SortField, error) {
+	if strings.TrimSpace(expr) == "" {
+		return DefaultSort, nil
+	}
+
+	var fields []SortField
+	seen := map[string]bool{}
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+
+		if _, ok := sortColumns[field.Field]; !ok {
+			return nil, fmt.Errorf("unknown sort field %q; use created_at, title, author or relevance", part)
+		}
+		if field.Field == SortRelevance {
+			if field.Desc {
+				return nil, fmt.Errorf("relevance cannot be sorted descending")
+			}
+			field.Desc = true
+		}
+		if seen[field.Field] {
+			return nil, fmt.Errorf("sort field %q is given more than once", field.Field)
+		}
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// FormatSort renders sort fields back into their canonical expression
+func FormatSort(fields []SortField) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.Field
+		if field.Desc && field.Field != SortRelevance {
+			parts[i] = "-" + field.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// SortsByRelevance reports whether an ordering uses the search rank
+func SortsByRelevance(fields []SortField) bool {
+	for _, field := range fields {
+		if field.Field == SortRelevance {
+			return true
+		}
+	}
+	return false
+}
+
+// sortKey is a resolved ordering column, ending with the id tiebreaker
+type sortKey struct {
+	column string
+	cast   string
+	desc   bool
+}
+
+// sortKeys resolves sort fields into ordering columns, reversing every
+// direction when paging backward. The id tiebreaker follows the primary field.
+func sortKeys(fields []SortField, backward bool) []sortKey {
+	keys := make([]sortKey, 0, len(fields)+1)
+	for _, field := range fields {
+		key := sortKey{column: sortColumns[field.Field], desc: field.Desc != backward}
+		if field.Field == "created_at" {
+			key.cast = "::timestamp"
+		}
+		keys = append(keys, key)
+	}
+	return append(keys, sortKey{column: "a.id", desc: keys[0].desc})
+}
+
+// orderByClause renders sort keys as an ORDER BY list
+func orderByClause(keys []sortKey) string {
+	terms := make([]string, len(keys))
+	for i, key := range keys {
+		terms[i] = key.column + " ASC"
+		if key.desc {
+			terms[i] = key.column + " DESC"
+		}
+	}
+	return strings.Join(terms, ", ")
+}
+
+// keysetCondition matches the rows that come after values in the order given by
+// keys, using placeholders from argIndex. Uniform directions use a row comparison
+// so the (created_at, id) order can be served from an index.
+func keysetCondition(keys []sortKey, values []string, argIndex int) (string, []interface{}) {
+	args := make([]interface{}, len(values))
+	placeholders := make([]string, len(keys))
+	for i, key := range keys {
+		args[i] = values[i]
+		placeholders[i] = fmt.Sprintf("$%d%s", argIndex+i, key.cast)
+	}
+
+	uniform := true
+	for _, key := range keys {
+		uniform = uniform && key.desc == keys[0].desc
+	}
+	if uniform {
+		columns := make([]string, len(keys))
+		for i, key := range keys {
+			columns[i] = key.column
+		}
+		comparison := ">"
+		if keys[0].desc {
+			comparison = "<"
+		}
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), comparison, strings.Join(placeholders, ", ")), args
+	}
+
+	// Mixed directions: (k1 after v1) OR (k1 = v1 AND k2 after v2) OR ...
+	var alternatives []string
+	for i, key := range keys {
+		var terms []string
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("%s = %s", keys[j].column, placeholders[j]))
+		}
+		comparison := ">"
+		if key.desc {
+			comparison = "<"
+		}
+		terms = append(terms, fmt.Sprintf("%s %s %s", key.column, comparison, placeholders[i]))
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// sortValues returns the sort key values of a listed article, ending with its id
+func sortValues(article models.ArticleListItem, fields []SortField) []string {
+	values := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		switch field.Field {
+		case "created_at":
+			values = append(values, article.CreatedAt.Format(time.RFC3339Nano))
+		case "title":
+			values = append(values, article.Title)
+		case "author":
+			name := ""
+			if article.Author != nil {
+				name = article.Author.Name
+			}
+			values = append(values, name)
+		}
+	}
+	return append(values, article.ID)
+}
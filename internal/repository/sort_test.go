@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	fields, err := ParseSort("author, -created_at")
+	if err != nil {
+		t.Fatalf("Failed to parse sort: %v", err)
+	}
+	expected := []SortField{{Field: "author"}, {Field: "created_at", Desc: true}}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, fields)
+	}
+	if FormatSort(fields) != "author,-created_at" {
+		t.Errorf("Unexpected canonical sort %q", FormatSort(fields))
+	}
+
+	if fields, _ := ParseSort(""); !reflect.DeepEqual(fields, DefaultSort) {
+		t.Errorf("Expected default sort, got %+v", fields)
+	}
+
+	for _, expr := range []string{"body", "-relevance", "title,-title", "created_at,"} {
+		if _, err := ParseSort(expr); err == nil {
+			t.Errorf("Expected an error for sort %q", expr)
+		}
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	keys := sortKeys(DefaultSort, false)
+	if orderByClause(keys) != "a.created_at DESC, a.id DESC" {
+		t.Errorf("Unexpected order by %q", orderByClause(keys))
+	}
+	condition, args := keysetCondition(keys, []string{"2025-09-04T10:30:00Z", "article-1"}, 3)
+	if condition != "(a.created_at, a.id) < ($3::timestamp, $4)" || len(args) != 2 {
+		t.Errorf("Unexpected uniform condition %q", condition)
+	}
+
+	// Paging backward over mixed directions reverses every key
+	keys = sortKeys([]SortField{{Field: "author"}, {Field: "created_at", Desc: true}}, true)
+	if orderByClause(keys) != "au.name DESC, a.created_at ASC, a.id DESC" {
+		t.Errorf("Unexpected order by %q", orderByClause(keys))
+	}
+	condition, _ = keysetCondition(keys, []string{"John Doe", "2025-09-04T10:30:00Z", "article-1"}, 1)
+	expected := "((au.name < $1) OR (au.name = $1 AND a.created_at > $2::timestamp) OR (au.name = $1 AND a.created_at = $2::timestamp AND a.id < $3))"
+	if condition != expected {
+		t.Errorf("Expected %q, got %q", expected, condition)
+	}
+}
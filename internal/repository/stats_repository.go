@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"fmt"
+
+	"article-api/internal/render"
+	"article-api/internal/textstats"
+
+	"github.com/lib/pq"
+)
+
+// articleStats computes the excerpt, word count and reading time of a body
+// from its text, without markup
+func articleStats(body, format string) (textstats.Stats, error) {
+	text, err := render.PlainText(body, format)
+	if err != nil {
+		return textstats.Stats{}, err
+	}
+	return textstats.Compute(text), nil
+}
+
+// BackfillArticleStats computes the excerpt, word count and reading time of
+// articles stored before they were persisted, such as seeded rows, in batches
+// of batchSize. With all set every article is recomputed. progress, when
+// given, is called with the running total after each batch. Trashed articles
+// are included so they are complete when restored.
+func (r *ArticleRepository) BackfillArticleStats(batchSize int, all bool, progress func(updated int)) (int, error) {
+	updated := 0
+	lastID := ""
+	for {
+		n, last, err := r.backfillStatsBatch(lastID, batchSize, all)
+		if err != nil {
+			return updated, err
+		}
+		if n == 0 {
+			break
+		}
+		updated += n
+		lastID = last
+		if progress != nil {
+			progress(updated)
+		}
+	}
+
+	if updated > 0 {
+		// Cached articles and listings were built without the stats
+		if cacheErr := r.cache.DeleteByPrefix(articleCacheKey("")); cacheErr != nil {
+			fmt.Printf("Failed to invalidate article cache: %v\n", cacheErr)
+		}
+		r.invalidateListCaches()
+	}
+
+	return updated, nil
+}
+
+// backfillStatsBatch updates the stats of up to batchSize articles after
+// lastID in one transaction, locking the rows so concurrent edits are not
+// overwritten with stale stats. It returns how many were updated and the last ID.
+func (r *ArticleRepository) backfillStatsBatch(lastID string, batchSize int, all bool) (int, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, body, content_format
+		FROM articles
+		WHERE id > $1 AND ($2 OR word_count = 0)
+		ORDER BY id
+		LIMIT $3
+		FOR UPDATE
+	`, lastID, all, batchSize)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to query articles: %w", err)
+	}
+	defer rows.Close()
+
+	var ids, excerpts []string
+	var wordCounts, readingTimes []int64
+	for rows.Next() {
+		var id, body, format string
+		if err := rows.Scan(&id, &body, &format); err != nil {
+			return 0, "", fmt.Errorf("failed to scan article: %w", err)
+		}
+		stats, err := articleStats(body, format)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to compute stats of article %s: %w", id, err)
+		}
+		ids = append(ids, id)
+		excerpts = append(excerpts, stats.Excerpt)
+		wordCounts = append(wordCounts, int64(stats.WordCount))
+		readingTimes = append(readingTimes, int64(stats.ReadingTimeMinutes))
+	}
+	if err := rows.Err(); err != nil {
+		return 0, "", fmt.Errorf("failed to read articles: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, "", nil
+	}
+
+	_, err = tx.Exec(`
+		UPDATE articles a
+		SET excerpt = x.excerpt,
+			word_count = x.word_count,
+			reading_time_minutes = x.reading_time_minutes
+		FROM unnest($1::text[], $2::text[], $3::int[], $4::int[]) AS x(id, excerpt, word_count, reading_time_minutes)
+		WHERE a.id = x.id
+	`, pq.Array(ids), pq.Array(excerpts), pq.Array(wordCounts), pq.Array(readingTimes))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to update article stats: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, "", fmt.Errorf("failed to commit article stats: %w", err)
+	}
+
+	return len(ids), ids[len(ids)-1], nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"article-api/internal/cache"
+	"article-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// TagRepository handles database operations for tags
+type TagRepository struct {
+	db    *sql.DB
+	cache cache.CacheServiceInterface
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db *sql.DB, cacheService cache.CacheServiceInterface) *TagRepository {
+	return &TagRepository{
+		db:    db,
+		cache: cacheService,
+	}
+}
+
+// setArticleTags replaces the tags of an article, creating missing tags
+func setArticleTags(q queryer, articleID string, tags []string) error {
+	if _, err := q.Exec(`DELETE FROM article_tags WHERE article_id = $1`, articleID); err != nil {
+		return fmt.Errorf("failed to clear article tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err := q.Exec(`INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`, pq.Array(tags))
+	if err != nil {
+		return fmt.Errorf("failed to create tags: %w", err)
+	}
+
+	_, err = q.Exec(`
+		INSERT INTO article_tags (article_id, tag_id)
+		SELECT $1, id FROM tags WHERE name = ANY($2)
+	`, articleID, pq.Array(tags))
+	if err != nil {
+		return fmt.Errorf("failed to tag article: %w", err)
+	}
+	return nil
+}
+
+// ListTags retrieves tags used by public articles with their usage counts,
+// most used first. A limit of zero returns every tag.
+func (r *TagRepository) ListTags(limit int) ([]models.Tag, error) {
+	query := `
+		SELECT t.name, COUNT(a.id) as count
+		FROM tags t
+		JOIN article_tags at ON at.tag_id = t.id
+		JOIN articles a ON a.id = at.article_id
+		WHERE a.status = 'published' AND a.deleted_at IS NULL
+		GROUP BY t.name
+		ORDER BY count DESC, t.name
+		LIMIT NULLIF($1, 0)
+	`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag renames a tag. Renaming onto an existing tag fails with
+// TagExistsError; use MergeTags for that instead.
+func (r *TagRepository) RenameTag(name, newName string) (*models.Tag, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var tagID int
+	err = tx.QueryRow(`SELECT id FROM tags WHERE name = $1 FOR UPDATE`, name).Scan(&tagID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &TagNotFoundError{Name: name}
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM tags WHERE name = $1 AND id <> $2)`, newName, tagID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check tag: %w", err)
+	}
+	if exists {
+		return nil, &TagExistsError{Name: newName}
+	}
+
+	if _, err := tx.Exec(`UPDATE tags SET name = $2 WHERE id = $1`, tagID, newName); err != nil {
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	affected, err := taggedArticleIDs(tx, []int{tagID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tag rename: %w", err)
+	}
+
+	r.invalidateTaggedArticles(affected)
+
+	return &models.Tag{Name: newName, Count: len(affected)}, nil
+}
+
+// MergeTags moves every article tagged with one of sources to target, creating
+// target if needed, and removes the source tags
+func (r *TagRepository) MergeTags(sources []string, target string) (*models.Tag, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT id, name FROM tags WHERE name = ANY($1) AND name <> $2 FOR UPDATE`, pq.Array(sources), target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source tags: %w", err)
+	}
+	var sourceIDs []int
+	found := map[string]bool{}
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		sourceIDs = append(sourceIDs, id)
+		found[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+	for _, source := range sources {
+		if source != target && !found[source] {
+			return nil, &TagNotFoundError{Name: source}
+		}
+	}
+
+	affected, err := taggedArticleIDs(tx, sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var targetID int
+	err = tx.QueryRow(`
+		INSERT INTO tags (name) VALUES ($1)
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id
+	`, target).Scan(&targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create target tag: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO article_tags (article_id, tag_id)
+		SELECT DISTINCT article_id, $2 FROM article_tags WHERE tag_id = ANY($1)
+		ON CONFLICT DO NOTHING
+	`, pq.Array(sourceIDs), targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM tags WHERE id = ANY($1)`, pq.Array(sourceIDs)); err != nil {
+		return nil, fmt.Errorf("failed to delete merged tags: %w", err)
+	}
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM article_tags WHERE tag_id = $1`, targetID).Scan(&count); err != nil {
+		return nil, fmt.Errorf("failed to count tag usage: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tag merge: %w", err)
+	}
+
+	r.invalidateTaggedArticles(affected)
+
+	return &models.Tag{Name: target, Count: count}, nil
+}
+
+// taggedArticleIDs returns the IDs of articles carrying any of the given tags
+func taggedArticleIDs(q queryer, tagIDs []int) ([]string, error) {
+	rows, err := q.Query(`SELECT DISTINCT article_id FROM article_tags WHERE tag_id = ANY($1)`, pq.Array(tagIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tagged articles: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan tagged article: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// invalidateTaggedArticles drops cached copies of articles whose tags changed,
+// and the listings and related lists they appear in
+func (r *TagRepository) invalidateTaggedArticles(ids []string) {
+	for _, id := range ids {
+		if cacheErr := r.cache.Delete(articleCacheKey(id)); cacheErr != nil {
+			// Log error but don't fail the request
+			fmt.Printf("Failed to invalidate article cache: %v\n", cacheErr)
+		}
+	}
+	if cacheErr := r.cache.DeleteByPrefix(listCachePrefix); cacheErr != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Failed to invalidate cache: %v\n", cacheErr)
+	}
+}
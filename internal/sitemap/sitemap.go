@@ -0,0 +1,79 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MaxURLs is the most URLs the sitemap protocol allows in a single sitemap;
+// larger sites are split into several sitemaps listed by a sitemap index
+const MaxURLs = 50000
+
+// namespace is the XML namespace of sitemaps and sitemap indexes
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL is a page listed in a sitemap
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// Sitemap is a sitemap listed in a sitemap index
+type Sitemap struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	XMLNS   string     `xml:"xmlns,attr"`
+	URLs    []location `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name   `xml:"sitemapindex"`
+	XMLNS    string     `xml:"xmlns,attr"`
+	Sitemaps []location `xml:"sitemap"`
+}
+
+// location is a <url> or <sitemap> element, which share their children
+type location struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// newLocation formats a location with a W3C datetime lastmod
+func newLocation(loc string, lastMod time.Time) location {
+	l := location{Loc: loc}
+	if !lastMod.IsZero() {
+		l.LastMod = lastMod.UTC().Format(time.RFC3339)
+	}
+	return l
+}
+
+// URLSet renders a sitemap of at most MaxURLs pages
+func URLSet(urls []URL) ([]byte, error) {
+	doc := urlSet{XMLNS: namespace}
+	for _, u := range urls {
+		doc.URLs = append(doc.URLs, newLocation(u.Loc, u.LastMod))
+	}
+	return render(doc)
+}
+
+// Index renders a sitemap index listing sitemaps
+func Index(sitemaps []Sitemap) ([]byte, error) {
+	doc := sitemapIndex{XMLNS: namespace}
+	for _, s := range sitemaps {
+		doc.Sitemaps = append(doc.Sitemaps, newLocation(s.Loc, s.LastMod))
+	}
+	return render(doc)
+}
+
+// render marshals a document with the XML declaration
+func render(doc interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
@@ -0,0 +1,48 @@
+package sitemap
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestURLSet(t *testing.T) {
+	body, err := URLSet([]URL{
+		{Loc: "http://example.com/articles/by-slug/a&b", LastMod: time.Date(2025, 1, 2, 10, 0, 0, 0, time.FixedZone("WIB", 7*3600))},
+		{Loc: "http://example.com/articles/by-slug/c"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to render sitemap: %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>http://example.com/articles/by-slug/a&amp;b</loc>
+    <lastmod>2025-01-02T03:00:00Z</lastmod>
+  </url>
+  <url>
+    <loc>http://example.com/articles/by-slug/c</loc>
+  </url>
+</urlset>
+`
+	if string(body) != expected {
+		t.Errorf("Unexpected sitemap:\n%s", body)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	body, err := Index([]Sitemap{
+		{Loc: "http://example.com/sitemaps/articles-1.xml", LastMod: time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{Loc: "http://example.com/sitemaps/articles-2.xml", LastMod: time.Date(2025, 2, 2, 3, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("Failed to render sitemap index: %v", err)
+	}
+
+	if !strings.Contains(string(body), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`) ||
+		strings.Count(string(body), "<sitemap>") != 2 ||
+		!strings.Contains(string(body), "<lastmod>2025-02-02T03:00:00Z</lastmod>") {
+		t.Errorf("Unexpected sitemap index:\n%s", body)
+	}
+}
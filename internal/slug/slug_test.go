@@ -0,0 +1,54 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"Getting Started with Go", "getting-started-with-go"},
+		{"  Go: Tips & Tricks!  ", "go-tips-tricks"},
+		{"Don't Panic", "dont-panic"},
+		{"Crème brûlée für Anfänger", "creme-brulee-fur-anfanger"},
+		{"Straße", "strasse"},
+		{"Привет, мир", "privet-mir"},
+		{"Καλημέρα", "kalimera"},
+		{"Café", "cafe"},
+		{"日本語", ""},
+		{"Go 1.21 release", "go-1-21-release"},
+	}
+
+	for _, tt := range tests {
+		if got := Make(tt.title); got != tt.expected {
+			t.Errorf("Make(%q) = %q, expected %q", tt.title, got, tt.expected)
+		}
+	}
+}
+
+func TestMakeTruncatesLongTitles(t *testing.T) {
+	title := strings.Repeat("average word ", 20)
+
+	got := Make(title)
+
+	if len(got) > MaxLength {
+		t.Errorf("Expected slug of at most %d bytes, got %d", MaxLength, len(got))
+	}
+	if strings.HasSuffix(got, "-") || strings.HasSuffix(got, "averag") {
+		t.Errorf("Expected slug to be cut at a word boundary, got %q", got)
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	if got := WithSuffix("hello-world", 2); got != "hello-world-2" {
+		t.Errorf("Expected 'hello-world-2', got %q", got)
+	}
+
+	long := Make(strings.Repeat("word ", 40))
+	if got := WithSuffix(long, 12); len(got) > MaxLength || !strings.HasSuffix(got, "-12") {
+		t.Errorf("Expected suffixed slug within %d bytes, got %q", MaxLength, got)
+	}
+}
@@ -0,0 +1,58 @@
+package textstats
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ExcerptLength is the maximum number of characters in an excerpt, not
+// counting the ellipsis added when the text is cut
+const ExcerptLength = 200
+
+// WordsPerMinute is the reading speed reading times are estimated with
+const WordsPerMinute = 200
+
+// Stats summarises a text for listings
+type Stats struct {
+	Excerpt            string
+	WordCount          int
+	ReadingTimeMinutes int
+}
+
+// Compute returns the stats of a plain text. The excerpt collapses whitespace
+// and, when the text is longer than ExcerptLength, is cut at a word boundary
+// and ends with an ellipsis. Reading times are rounded up to whole minutes.
+func Compute(text string) Stats {
+	words := strings.Fields(text)
+	return Stats{
+		Excerpt:            excerpt(words),
+		WordCount:          len(words),
+		ReadingTimeMinutes: (len(words) + WordsPerMinute - 1) / WordsPerMinute,
+	}
+}
+
+// excerpt joins words until the next one would exceed ExcerptLength
+func excerpt(words []string) string {
+	var b strings.Builder
+	length := 0
+	for _, word := range words {
+		n := utf8.RuneCountInString(word)
+		if length > 0 {
+			n++
+		}
+		if length+n > ExcerptLength {
+			if length == 0 {
+				// A single overlong word is cut mid-word
+				b.WriteString(string([]rune(word)[:ExcerptLength]))
+			}
+			b.WriteString("…")
+			break
+		}
+		if length > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+		length += n
+	}
+	return b.String()
+}
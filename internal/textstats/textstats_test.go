@@ -0,0 +1,59 @@
+package textstats
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCompute(t *testing.T) {
+	stats := Compute("  Hello,\n\n  wide\tworld!  ")
+
+	if stats.Excerpt != "Hello, wide world!" {
+		t.Errorf("Expected collapsed whitespace, got %q", stats.Excerpt)
+	}
+	if stats.WordCount != 3 || stats.ReadingTimeMinutes != 1 {
+		t.Errorf("Expected 3 words and 1 minute, got %d and %d", stats.WordCount, stats.ReadingTimeMinutes)
+	}
+
+	if empty := Compute(""); empty != (Stats{}) {
+		t.Errorf("Expected empty stats, got %+v", empty)
+	}
+}
+
+func TestComputeReadingTime(t *testing.T) {
+	tests := []struct {
+		words    int
+		expected int
+	}{
+		{1, 1},
+		{WordsPerMinute, 1},
+		{WordsPerMinute + 1, 2},
+		{WordsPerMinute * 5, 5},
+	}
+
+	for _, tt := range tests {
+		text := strings.Repeat("word ", tt.words)
+		if got := Compute(text).ReadingTimeMinutes; got != tt.expected {
+			t.Errorf("Reading time of %d words = %d, expected %d", tt.words, got, tt.expected)
+		}
+	}
+}
+
+func TestComputeExcerpt(t *testing.T) {
+	text := strings.Repeat("café ", 100)
+
+	got := Compute(text).Excerpt
+
+	if !strings.HasSuffix(got, "café…") {
+		t.Errorf("Expected the excerpt to end at a word with an ellipsis, got %q", got)
+	}
+	if n := utf8.RuneCountInString(strings.TrimSuffix(got, "…")); n > ExcerptLength {
+		t.Errorf("Expected at most %d characters, got %d", ExcerptLength, n)
+	}
+
+	long := Compute(strings.Repeat("x", ExcerptLength+50)).Excerpt
+	if long != strings.Repeat("x", ExcerptLength)+"…" {
+		t.Errorf("Expected an overlong word to be cut, got %q", long)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"article-api/internal/config"
 	"article-api/internal/database"
 	"article-api/internal/handlers"
+	"article-api/internal/jobs"
 	"article-api/internal/migration"
 	"article-api/internal/repository"
 )
@@ -20,6 +21,12 @@ import (
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
+	if cfg.App.BaseURL == "" {
+		log.Printf("Warning: BASE_URL is not set, feeds and sitemaps are unavailable")
+	}
+	if os.Getenv("CURSOR_SECRET") == "" {
+		log.Printf("Warning: CURSOR_SECRET is not set, signing cursors with a random secret that changes on restart")
+	}
 
 	// Initialize database connection
 	db, err := database.Connect()
@@ -33,31 +40,207 @@ func main() {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
-	// Initialize Redis cache (fallback to mock if Redis unavailable)
+	// Initialize Redis cache (fallback to mock if Redis unavailable). Without
+	// Redis, idempotency records go to PostgreSQL so they survive restarts.
 	var cacheService cache.CacheServiceInterface
+	var idempotencyStore repository.IdempotencyStoreInterface
 	redisCache, err := cache.NewCacheService()
 	if err != nil {
 		log.Printf("Warning: Failed to connect to Redis (%v), using mock cache", err)
 		cacheService = cache.NewMockCacheService()
+		idempotencyStore = repository.NewIdempotencyRepository(db)
 	} else {
 		cacheService = redisCache
 		defer cacheService.Close()
+		idempotencyStore = repository.NewCacheIdempotencyStore(cacheService)
 	}
 
-	// Initialize repository with cache
+	// Initialize repositories with cache
 	articleRepo := repository.NewArticleRepository(db, cacheService)
+	tagRepo := repository.NewTagRepository(db, cacheService)
+
+	// Start background jobs; they stop when the server shuts down
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobs.StartTrashPurger(jobsCtx, articleRepo, cfg.App.TrashRetention, cfg.App.TrashPurgeInterval)
+	jobs.StartPublishScheduler(jobsCtx, articleRepo, cfg.App.PublishInterval)
 
 	// Initialize handlers
 	articleHandler := handlers.NewArticleHandler(articleRepo)
+	tagHandler := handlers.NewTagHandler(tagRepo)
+	feedHandler := handlers.NewFeedHandler(articleRepo, cacheService)
+	sitemapHandler := handlers.NewSitemapHandler(articleRepo, cacheService)
+	idempotency := handlers.NewIdempotency(idempotencyStore)
 
 	// Setup routes
 	router := http.NewServeMux()
-	router.HandleFunc("/articles", func(w http.ResponseWriter, r *http.Request) {
+	// API endpoints speak JSON, XML, MessagePack and CBOR; feeds, sitemaps and
+	// exports have formats of their own
+	router.HandleFunc("/articles", handlers.Negotiate(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case "GET":
 			articleHandler.ListArticles(w, r)
 		case "POST":
-			articleHandler.CreateArticle(w, r)
+			idempotency.Wrap(articleHandler.CreateArticle)(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
+	router.HandleFunc("/articles/export", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			articleHandler.ExportArticles(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	router.HandleFunc("/articles/", handlers.Negotiate(func(w http.ResponseWriter, r *http.Request) {
+		segments := handlers.PathSegments(r.URL.Path, "/articles/")
+		switch {
+		case len(segments) == 2 && segments[0] == "by-slug":
+			switch r.Method {
+			case "GET":
+				articleHandler.GetArticleBySlug(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case len(segments) == 1 && segments[0] == "batch":
+			switch r.Method {
+			case "POST":
+				articleHandler.BatchCreateArticles(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case len(segments) == 1:
+			switch r.Method {
+			case "GET":
+				articleHandler.GetArticle(w, r)
+			case "PUT":
+				articleHandler.UpdateArticle(w, r)
+			case "PATCH":
+				articleHandler.PatchArticle(w, r)
+			case "DELETE":
+				articleHandler.DeleteArticle(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case len(segments) == 2 && segments[1] == "related":
+			switch r.Method {
+			case "GET":
+				articleHandler.RelatedArticles(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case len(segments) == 2 && segments[1] == "restore":
+			switch r.Method {
+			case "POST":
+				articleHandler.RestoreArticle(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case len(segments) >= 2 && len(segments) <= 3 && segments[1] == "revisions":
+			if r.Method != "GET" {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			switch {
+			case len(segments) == 2:
+				articleHandler.ListRevisions(w, r)
+			case segments[2] == "diff":
+				articleHandler.DiffRevisions(w, r)
+			default:
+				articleHandler.GetRevision(w, r)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	router.HandleFunc("/tags", handlers.Negotiate(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			tagHandler.ListTags(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
+	router.HandleFunc("/tags/", func(w http.ResponseWriter, r *http.Request) {
+		segments := handlers.PathSegments(r.URL.Path, "/tags/")
+		switch {
+		case len(segments) == 1 && segments[0] == "merge":
+			switch r.Method {
+			case "POST":
+				handlers.Negotiate(tagHandler.MergeTags)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case len(segments) == 1:
+			switch r.Method {
+			case "PATCH":
+				handlers.Negotiate(tagHandler.RenameTag)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case len(segments) == 2 && segments[1] == "feed.atom":
+			switch r.Method {
+			case "GET", "HEAD":
+				feedHandler.TagFeed(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	router.HandleFunc("/authors/", func(w http.ResponseWriter, r *http.Request) {
+		segments := handlers.PathSegments(r.URL.Path, "/authors/")
+		switch {
+		case len(segments) == 2 && segments[1] == "feed.atom":
+			switch r.Method {
+			case "GET", "HEAD":
+				feedHandler.AuthorFeed(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	router.HandleFunc("/feeds/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" && r.Method != "HEAD" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/feeds/articles.atom":
+			feedHandler.ArticlesAtom(w, r)
+		case "/feeds/articles.rss":
+			feedHandler.ArticlesRSS(w, r)
+		case "/feeds/articles.json":
+			feedHandler.ArticlesJSON(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	router.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET", "HEAD":
+			sitemapHandler.Sitemap(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	router.HandleFunc("/sitemaps/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET", "HEAD":
+			sitemapHandler.ArticlesSitemap(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -88,6 +271,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Server shutting down...")
+	stopJobs()
 
 	// Create a deadline for shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
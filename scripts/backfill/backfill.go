@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"article-api/internal/cache"
+	"article-api/internal/database"
+	"article-api/internal/repository"
+
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	batchSize := flag.Int("batch-size", 500, "articles updated per transaction")
+	all := flag.Bool("all", false, "recompute every article, not only those without stats")
+	flag.Parse()
+
+	if *batchSize < 1 {
+		log.Fatal("batch-size must be positive")
+	}
+
+	// Connect to database
+	db, err := database.Connect()
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	// Updated articles invalidate cached articles and listings when Redis is available
+	var cacheService cache.CacheServiceInterface
+	redisCache, err := cache.NewCacheService()
+	if err != nil {
+		log.Printf("Warning: Failed to connect to Redis (%v), cached articles will expire on their own", err)
+		cacheService = cache.NewMockCacheService()
+	} else {
+		cacheService = redisCache
+		defer cacheService.Close()
+	}
+	articleRepo := repository.NewArticleRepository(db, cacheService)
+
+	updated, err := articleRepo.BackfillArticleStats(*batchSize, *all, func(updated int) {
+		fmt.Printf("Updated %d articles\n", updated)
+	})
+	if err != nil {
+		log.Fatalf("Backfill stopped after %d articles (rerun to continue): %v", updated, err)
+	}
+
+	fmt.Printf("Backfill completed: %d articles updated\n", updated)
+}
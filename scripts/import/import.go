@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"article-api/internal/cache"
+	"article-api/internal/database"
+	"article-api/internal/importer"
+	"article-api/internal/repository"
+
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	file := flag.String("file", "", "NDJSON or CSV file to import")
+	format := flag.String("format", "", "input format: ndjson or csv (default: from the file extension)")
+	batchSize := flag.Int("batch-size", 500, "articles per multi-row INSERT")
+	checkpoint := flag.String("checkpoint", "", "checkpoint file used to resume (default: <file>.checkpoint)")
+	rejects := flag.String("rejects", "", "rejected rows file (default: <file>.rejected.ndjson)")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("Usage: go run scripts/import/import.go -file articles.ndjson")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+		if *format == "jsonl" {
+			*format = importer.FormatNDJSON
+		}
+	}
+	if *checkpoint == "" {
+		*checkpoint = *file + ".checkpoint"
+	}
+	if *rejects == "" {
+		*rejects = *file + ".rejected.ndjson"
+	}
+	if *batchSize > repository.MaxImportBatch {
+		log.Fatalf("batch-size cannot exceed %d", repository.MaxImportBatch)
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		log.Fatal("Failed to open input:", err)
+	}
+	defer input.Close()
+
+	// A resumed run continues the rejected rows file where the checkpoint left
+	// it, dropping rows written after it; a fresh run starts the file over
+	resumed, err := importer.LoadCheckpoint(*checkpoint)
+	if err != nil {
+		log.Fatal("Failed to read checkpoint:", err)
+	}
+	rejectsFile, err := os.OpenFile(*rejects, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		log.Fatal("Failed to open rejected rows file:", err)
+	}
+	defer rejectsFile.Close()
+	if err := rejectsFile.Truncate(resumed.RejectsSize); err != nil {
+		log.Fatal("Failed to truncate rejected rows file:", err)
+	}
+	if _, err := rejectsFile.Seek(0, io.SeekEnd); err != nil {
+		log.Fatal("Failed to seek rejected rows file:", err)
+	}
+
+	// Connect to database
+	db, err := database.Connect()
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	// Imported articles invalidate cached listings when Redis is available
+	var cacheService cache.CacheServiceInterface
+	redisCache, err := cache.NewCacheService()
+	if err != nil {
+		log.Printf("Warning: Failed to connect to Redis (%v), cached listings will expire on their own", err)
+		cacheService = cache.NewMockCacheService()
+	} else {
+		cacheService = redisCache
+		defer cacheService.Close()
+	}
+	articleRepo := repository.NewArticleRepository(db, cacheService)
+
+	// Interrupting finishes the current batch and keeps the checkpoint
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	start := time.Now()
+	progress, err := importer.Run(ctx, input, articleRepo, importer.Options{
+		Format:         *format,
+		BatchSize:      *batchSize,
+		CheckpointPath: *checkpoint,
+		Rejects:        rejectsFile,
+		OnProgress: func(p importer.Progress) {
+			elapsed := time.Since(start).Round(time.Second)
+			fmt.Printf("Processed %d records: %d imported, %d rejected (%s elapsed)\n", p.Records, p.Imported, p.Rejected, elapsed)
+		},
+	})
+	if err != nil {
+		log.Fatalf("Import stopped after %d records (rerun to resume): %v", progress.Records, err)
+	}
+
+	fmt.Printf("Import completed: %d imported, %d rejected (see %s)\n", progress.Imported, progress.Rejected, *rejects)
+	if err := os.Remove(*checkpoint); err != nil && !os.IsNotExist(err) {
+		log.Printf("Warning: failed to remove checkpoint %s: %v", *checkpoint, err)
+	}
+}
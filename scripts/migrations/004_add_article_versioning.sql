@@ -0,0 +1,5 @@
+-- Migration: Add optimistic concurrency columns to articles
+-- Created: 2025-09-10
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,6 @@
+-- Migration: Add soft delete support to articles
+-- Created: 2025-09-12
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_articles_deleted_at ON articles (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,60 @@
+-- Migration: Create article revisions table
+-- Created: 2025-09-15
+
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id SERIAL PRIMARY KEY,
+    article_id TEXT NOT NULL,
+    revision INTEGER NOT NULL,
+    author_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    edited_by TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (article_id, revision),
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+-- Record a revision whenever an article is created or its title/body changes.
+-- The editor is taken from the article_api.edited_by setting of the current
+-- transaction and defaults to the article author.
+CREATE OR REPLACE FUNCTION record_article_revision() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.title IS DISTINCT FROM OLD.title OR NEW.body IS DISTINCT FROM OLD.body THEN
+        INSERT INTO article_revisions (article_id, revision, author_id, title, body, edited_by, created_at)
+        VALUES (
+            NEW.id,
+            NEW.version,
+            NEW.author_id,
+            NEW.title,
+            NEW.body,
+            COALESCE(NULLIF(current_setting('article_api.edited_by', true), ''), NEW.author_id),
+            NEW.updated_at
+        )
+        ON CONFLICT (article_id, revision) DO NOTHING;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS articles_record_revision ON articles;
+CREATE TRIGGER articles_record_revision
+    AFTER INSERT OR UPDATE OF title, body ON articles
+    FOR EACH ROW EXECUTE FUNCTION record_article_revision();
+
+-- Revisions are immutable history
+CREATE OR REPLACE FUNCTION reject_revision_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'article revisions are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS article_revisions_immutable ON article_revisions;
+CREATE TRIGGER article_revisions_immutable
+    BEFORE UPDATE ON article_revisions
+    FOR EACH ROW EXECUTE FUNCTION reject_revision_update();
+
+-- Seed the history with the current state of existing articles
+INSERT INTO article_revisions (article_id, revision, author_id, title, body, edited_by, created_at)
+SELECT id, version, author_id, title, body, author_id, updated_at
+FROM articles
+ON CONFLICT (article_id, revision) DO NOTHING;
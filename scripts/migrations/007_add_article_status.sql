@@ -0,0 +1,11 @@
+-- Migration: Add publication lifecycle to articles
+-- Created: 2025-09-18
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published'
+    CHECK (status IN ('draft', 'scheduled', 'published', 'archived'));
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS published_at TIMESTAMP NULL;
+
+-- Existing articles were published when they were created
+UPDATE articles SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_articles_status_published_at ON articles (status, published_at);
@@ -0,0 +1,48 @@
+-- Migration: Add unique slugs and slug history to articles
+-- Created: 2025-09-22
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS slug TEXT;
+
+-- Backfill existing articles with an ASCII slug of their title
+UPDATE articles
+SET slug = COALESCE(NULLIF(trim(both '-' from regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g')), ''), 'article')
+WHERE slug IS NULL;
+
+-- Resolve collisions from the backfill with a numeric suffix, oldest article
+-- first. Each suffix is checked against every slug assigned so far, since a
+-- title like "Foo 2" already owns "foo-2".
+DO $$
+DECLARE
+    dup RECORD;
+    n INTEGER;
+BEGIN
+    FOR dup IN
+        SELECT id, slug
+        FROM (
+            SELECT id, slug, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY created_at, id) AS rn
+            FROM articles
+        ) ranked
+        WHERE rn > 1
+        ORDER BY slug, rn
+    LOOP
+        n := 2;
+        WHILE EXISTS (SELECT 1 FROM articles WHERE slug = dup.slug || '-' || n) LOOP
+            n := n + 1;
+        END LOOP;
+        UPDATE articles SET slug = dup.slug || '-' || n WHERE id = dup.id;
+    END LOOP;
+END;
+$$;
+
+ALTER TABLE articles ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_slug ON articles (slug);
+
+-- Previous slugs keep resolving to their article after a title change
+CREATE TABLE IF NOT EXISTS article_slug_history (
+    slug TEXT PRIMARY KEY,
+    article_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_slug_history_article_id ON article_slug_history (article_id);
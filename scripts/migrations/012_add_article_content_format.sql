@@ -0,0 +1,5 @@
+-- Migration: Add body content formats to articles
+-- Created: 2025-10-07
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS content_format TEXT NOT NULL DEFAULT 'plain'
+    CHECK (content_format IN ('plain', 'markdown', 'html'));
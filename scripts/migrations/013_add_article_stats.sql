@@ -0,0 +1,8 @@
+-- Migration: Persist article excerpts, word counts and reading times
+-- Created: 2025-10-08
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS excerpt TEXT NOT NULL DEFAULT '';
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS word_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS reading_time_minutes INTEGER NOT NULL DEFAULT 0;
+
+-- Existing and seeded rows are filled in by scripts/backfill (make backfill)
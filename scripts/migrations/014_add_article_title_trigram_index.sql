@@ -0,0 +1,6 @@
+-- Migration: Index article titles by trigrams for related articles
+-- Created: 2025-10-09
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_articles_title_trgm ON articles USING GIN (title gin_trgm_ops);
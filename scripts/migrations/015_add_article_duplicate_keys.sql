@@ -0,0 +1,11 @@
+-- Migration: Add normalized title and body keys for duplicate detection
+-- Created: 2025-10-10
+
+-- Titles and bodies are compared case-insensitively with whitespace collapsed
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS title_key TEXT
+    GENERATED ALWAYS AS (lower(regexp_replace(btrim(title), '\s+', ' ', 'g'))) STORED;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS body_hash TEXT
+    GENERATED ALWAYS AS (md5(lower(regexp_replace(btrim(body), '\s+', ' ', 'g')))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_articles_author_title_key ON articles (author_id, title_key);
+CREATE INDEX IF NOT EXISTS idx_articles_author_body_hash ON articles (author_id, body_hash);
@@ -1,24 +1,24 @@
 -- Seeder: Insert comprehensive articles with longer content
 -- Created: 2025-09-04
 
-INSERT INTO articles (id, author_id, title, body) VALUES 
-    ('article-101', 'author-1', 'The Future of Web Development: Trends and Technologies', 'Web development has evolved dramatically over the past decade, and the pace of change shows no signs of slowing down. From the rise of JavaScript frameworks like React, Vue, and Angular to the emergence of serverless architectures and edge computing, developers are constantly adapting to new paradigms and tools. This comprehensive guide explores the most significant trends shaping the future of web development, including the growing importance of performance optimization, accessibility, and user experience. We''ll also examine how artificial intelligence and machine learning are being integrated into web applications, from chatbots and recommendation systems to automated testing and code generation. The shift towards progressive web apps (PWAs) and the increasing adoption of WebAssembly for high-performance applications are also key areas of focus. Additionally, we''ll discuss the impact of new CSS features, the evolution of build tools and bundlers, and the growing emphasis on developer experience and tooling. As we look ahead, it''s clear that web development will continue to be a dynamic and exciting field, with new challenges and opportunities emerging regularly.'),
+INSERT INTO articles (id, author_id, title, slug, body) VALUES 
+    ('article-101', 'author-1', 'The Future of Web Development: Trends and Technologies', 'the-future-of-web-development-trends-and-technologies', 'Web development has evolved dramatically over the past decade, and the pace of change shows no signs of slowing down. From the rise of JavaScript frameworks like React, Vue, and Angular to the emergence of serverless architectures and edge computing, developers are constantly adapting to new paradigms and tools. This comprehensive guide explores the most significant trends shaping the future of web development, including the growing importance of performance optimization, accessibility, and user experience. We''ll also examine how artificial intelligence and machine learning are being integrated into web applications, from chatbots and recommendation systems to automated testing and code generation. The shift towards progressive web apps (PWAs) and the increasing adoption of WebAssembly for high-performance applications are also key areas of focus. Additionally, we''ll discuss the impact of new CSS features, the evolution of build tools and bundlers, and the growing emphasis on developer experience and tooling. As we look ahead, it''s clear that web development will continue to be a dynamic and exciting field, with new challenges and opportunities emerging regularly.'),
     
-    ('article-102', 'author-2', 'Database Design Patterns: From Relational to NoSQL', 'Database design is a critical aspect of software development that directly impacts application performance, scalability, and maintainability. This in-depth exploration covers the fundamental principles of database design, from traditional relational database patterns to modern NoSQL approaches. We begin by examining the core concepts of normalization and denormalization, exploring when each approach is most appropriate and the trade-offs involved. The article then delves into various database design patterns, including the repository pattern, unit of work pattern, and CQRS (Command Query Responsibility Segregation). We also explore the differences between SQL and NoSQL databases, examining use cases for document stores like MongoDB, key-value stores like Redis, and graph databases like Neo4j. Performance optimization techniques are covered in detail, including indexing strategies, query optimization, and connection pooling. The article also addresses modern challenges such as handling big data, implementing real-time analytics, and designing for microservices architectures. Security considerations, including data encryption, access control, and compliance with regulations like GDPR, are also thoroughly discussed.'),
+    ('article-102', 'author-2', 'Database Design Patterns: From Relational to NoSQL', 'database-design-patterns-from-relational-to-nosql', 'Database design is a critical aspect of software development that directly impacts application performance, scalability, and maintainability. This in-depth exploration covers the fundamental principles of database design, from traditional relational database patterns to modern NoSQL approaches. We begin by examining the core concepts of normalization and denormalization, exploring when each approach is most appropriate and the trade-offs involved. The article then delves into various database design patterns, including the repository pattern, unit of work pattern, and CQRS (Command Query Responsibility Segregation). We also explore the differences between SQL and NoSQL databases, examining use cases for document stores like MongoDB, key-value stores like Redis, and graph databases like Neo4j. Performance optimization techniques are covered in detail, including indexing strategies, query optimization, and connection pooling. The article also addresses modern challenges such as handling big data, implementing real-time analytics, and designing for microservices architectures. Security considerations, including data encryption, access control, and compliance with regulations like GDPR, are also thoroughly discussed.'),
     
-    ('article-103', 'author-3', 'Microservices Architecture: Best Practices and Common Pitfalls', 'Microservices architecture has become the de facto standard for building large-scale, distributed applications. This comprehensive guide examines the benefits and challenges of microservices, providing practical insights for teams considering or implementing this architectural pattern. We start by defining what microservices are and how they differ from monolithic architectures, exploring the key principles of service independence, decentralized data management, and fault tolerance. The article covers essential topics such as service discovery, API gateway patterns, and inter-service communication strategies. We examine different approaches to data consistency in distributed systems, including eventual consistency, saga patterns, and event sourcing. The guide also addresses operational concerns such as monitoring, logging, and debugging in microservices environments. Common pitfalls and anti-patterns are discussed in detail, helping teams avoid costly mistakes during implementation. We explore deployment strategies, including containerization with Docker and orchestration with Kubernetes, as well as CI/CD pipelines for microservices. The article concludes with practical recommendations for team organization, testing strategies, and gradual migration from monolithic to microservices architectures.'),
+    ('article-103', 'author-3', 'Microservices Architecture: Best Practices and Common Pitfalls', 'microservices-architecture-best-practices-and-common-pitfalls', 'Microservices architecture has become the de facto standard for building large-scale, distributed applications. This comprehensive guide examines the benefits and challenges of microservices, providing practical insights for teams considering or implementing this architectural pattern. We start by defining what microservices are and how they differ from monolithic architectures, exploring the key principles of service independence, decentralized data management, and fault tolerance. The article covers essential topics such as service discovery, API gateway patterns, and inter-service communication strategies. We examine different approaches to data consistency in distributed systems, including eventual consistency, saga patterns, and event sourcing. The guide also addresses operational concerns such as monitoring, logging, and debugging in microservices environments. Common pitfalls and anti-patterns are discussed in detail, helping teams avoid costly mistakes during implementation. We explore deployment strategies, including containerization with Docker and orchestration with Kubernetes, as well as CI/CD pipelines for microservices. The article concludes with practical recommendations for team organization, testing strategies, and gradual migration from monolithic to microservices architectures.'),
     
-    ('article-104', 'author-4', 'API Design Principles: Building Developer-Friendly Interfaces', 'Creating well-designed APIs is crucial for the success of any software platform or service. This comprehensive guide covers the fundamental principles of API design, from RESTful conventions to modern GraphQL approaches. We begin by exploring the core principles of good API design, including consistency, simplicity, and developer experience. The article covers essential topics such as resource modeling, HTTP methods and status codes, and proper error handling. We examine different API styles, including REST, GraphQL, and gRPC, discussing their strengths and use cases. Authentication and authorization patterns are covered in detail, including OAuth 2.0, JWT tokens, and API key management. The guide also addresses performance considerations such as caching strategies, rate limiting, and pagination. Versioning strategies and backward compatibility are explored, along with documentation best practices and tools for API testing. We also discuss modern trends such as API-first development, OpenAPI specifications, and the growing importance of API security. The article concludes with practical examples and case studies from successful API implementations.'),
+    ('article-104', 'author-4', 'API Design Principles: Building Developer-Friendly Interfaces', 'api-design-principles-building-developer-friendly-interfaces', 'Creating well-designed APIs is crucial for the success of any software platform or service. This comprehensive guide covers the fundamental principles of API design, from RESTful conventions to modern GraphQL approaches. We begin by exploring the core principles of good API design, including consistency, simplicity, and developer experience. The article covers essential topics such as resource modeling, HTTP methods and status codes, and proper error handling. We examine different API styles, including REST, GraphQL, and gRPC, discussing their strengths and use cases. Authentication and authorization patterns are covered in detail, including OAuth 2.0, JWT tokens, and API key management. The guide also addresses performance considerations such as caching strategies, rate limiting, and pagination. Versioning strategies and backward compatibility are explored, along with documentation best practices and tools for API testing. We also discuss modern trends such as API-first development, OpenAPI specifications, and the growing importance of API security. The article concludes with practical examples and case studies from successful API implementations.'),
     
-    ('article-105', 'author-5', 'Cloud Computing: From Infrastructure to Platform Services', 'Cloud computing has revolutionized how organizations build, deploy, and scale applications. This comprehensive overview explores the evolution of cloud services from basic infrastructure offerings to sophisticated platform and software services. We begin by examining the three main service models: Infrastructure as a Service (IaaS), Platform as a Service (PaaS), and Software as a Service (SaaS). The article covers major cloud providers including AWS, Azure, and Google Cloud Platform, comparing their offerings and strengths. We explore key cloud concepts such as virtualization, containerization, and serverless computing, examining how these technologies enable greater flexibility and cost efficiency. The guide addresses important considerations such as cloud security, compliance, and data governance. We also discuss migration strategies for moving applications to the cloud, including lift-and-shift approaches and cloud-native redesigns. Cost optimization techniques and monitoring strategies are covered in detail. The article concludes with insights into emerging trends such as edge computing, multi-cloud strategies, and the growing importance of cloud-native development practices.'),
+    ('article-105', 'author-5', 'Cloud Computing: From Infrastructure to Platform Services', 'cloud-computing-from-infrastructure-to-platform-services', 'Cloud computing has revolutionized how organizations build, deploy, and scale applications. This comprehensive overview explores the evolution of cloud services from basic infrastructure offerings to sophisticated platform and software services. We begin by examining the three main service models: Infrastructure as a Service (IaaS), Platform as a Service (PaaS), and Software as a Service (SaaS). The article covers major cloud providers including AWS, Azure, and Google Cloud Platform, comparing their offerings and strengths. We explore key cloud concepts such as virtualization, containerization, and serverless computing, examining how these technologies enable greater flexibility and cost efficiency. The guide addresses important considerations such as cloud security, compliance, and data governance. We also discuss migration strategies for moving applications to the cloud, including lift-and-shift approaches and cloud-native redesigns. Cost optimization techniques and monitoring strategies are covered in detail. The article concludes with insights into emerging trends such as edge computing, multi-cloud strategies, and the growing importance of cloud-native development practices.'),
     
-    ('article-106', 'author-6', 'DevOps Culture: Bridging Development and Operations', 'DevOps represents a cultural and technical shift that emphasizes collaboration between development and operations teams. This comprehensive guide explores the principles, practices, and tools that enable organizations to achieve faster, more reliable software delivery. We begin by examining the core values of DevOps: culture, automation, measurement, and sharing. The article covers essential practices such as continuous integration and continuous deployment (CI/CD), infrastructure as code, and automated testing. We explore popular DevOps tools and platforms, including Jenkins, GitLab CI, GitHub Actions, and cloud-native solutions. The guide addresses key concepts such as version control strategies, branching models, and code review processes. We examine monitoring and observability practices, including logging, metrics, and distributed tracing. The article also covers security considerations in DevOps, including DevSecOps practices and automated security scanning. Team organization and collaboration strategies are discussed, along with metrics for measuring DevOps success. We conclude with practical advice for organizations looking to adopt DevOps practices and common challenges they may face.'),
+    ('article-106', 'author-6', 'DevOps Culture: Bridging Development and Operations', 'devops-culture-bridging-development-and-operations', 'DevOps represents a cultural and technical shift that emphasizes collaboration between development and operations teams. This comprehensive guide explores the principles, practices, and tools that enable organizations to achieve faster, more reliable software delivery. We begin by examining the core values of DevOps: culture, automation, measurement, and sharing. The article covers essential practices such as continuous integration and continuous deployment (CI/CD), infrastructure as code, and automated testing. We explore popular DevOps tools and platforms, including Jenkins, GitLab CI, GitHub Actions, and cloud-native solutions. The guide addresses key concepts such as version control strategies, branching models, and code review processes. We examine monitoring and observability practices, including logging, metrics, and distributed tracing. The article also covers security considerations in DevOps, including DevSecOps practices and automated security scanning. Team organization and collaboration strategies are discussed, along with metrics for measuring DevOps success. We conclude with practical advice for organizations looking to adopt DevOps practices and common challenges they may face.'),
     
-    ('article-107', 'author-7', 'Machine Learning in Production: From Model to Deployment', 'Deploying machine learning models in production environments presents unique challenges that differ significantly from traditional software deployment. This comprehensive guide covers the entire ML lifecycle, from data preparation and model training to deployment and monitoring. We begin by exploring the MLOps (Machine Learning Operations) framework and its key components. The article covers data management strategies, including data versioning, feature stores, and data quality monitoring. We examine different approaches to model training, including batch processing, online learning, and federated learning. The guide addresses model deployment patterns, including batch inference, real-time serving, and edge deployment. We explore monitoring and observability for ML systems, including model drift detection, performance monitoring, and A/B testing frameworks. The article also covers important considerations such as model explainability, fairness, and bias detection. We discuss infrastructure requirements, including GPU computing, distributed training, and scalable serving architectures. The guide concludes with best practices for maintaining ML systems in production and common pitfalls to avoid.'),
+    ('article-107', 'author-7', 'Machine Learning in Production: From Model to Deployment', 'machine-learning-in-production-from-model-to-deployment', 'Deploying machine learning models in production environments presents unique challenges that differ significantly from traditional software deployment. This comprehensive guide covers the entire ML lifecycle, from data preparation and model training to deployment and monitoring. We begin by exploring the MLOps (Machine Learning Operations) framework and its key components. The article covers data management strategies, including data versioning, feature stores, and data quality monitoring. We examine different approaches to model training, including batch processing, online learning, and federated learning. The guide addresses model deployment patterns, including batch inference, real-time serving, and edge deployment. We explore monitoring and observability for ML systems, including model drift detection, performance monitoring, and A/B testing frameworks. The article also covers important considerations such as model explainability, fairness, and bias detection. We discuss infrastructure requirements, including GPU computing, distributed training, and scalable serving architectures. The guide concludes with best practices for maintaining ML systems in production and common pitfalls to avoid.'),
     
-    ('article-108', 'author-8', 'Cybersecurity Best Practices for Modern Applications', 'As applications become more complex and interconnected, cybersecurity has become a critical concern for developers and organizations. This comprehensive guide covers essential security practices for modern application development. We begin by examining the current threat landscape and common attack vectors, including OWASP Top 10 vulnerabilities. The article covers authentication and authorization best practices, including multi-factor authentication, OAuth 2.0, and role-based access control. We explore data protection strategies, including encryption at rest and in transit, secure key management, and data anonymization techniques. The guide addresses application security testing, including static analysis, dynamic testing, and penetration testing. We examine secure coding practices for different programming languages and frameworks. The article also covers infrastructure security, including container security, network segmentation, and cloud security best practices. We discuss incident response planning and security monitoring strategies. The guide concludes with compliance considerations, including GDPR, HIPAA, and SOC 2 requirements, and practical steps for implementing a security-first development culture.'),
+    ('article-108', 'author-8', 'Cybersecurity Best Practices for Modern Applications', 'cybersecurity-best-practices-for-modern-applications', 'As applications become more complex and interconnected, cybersecurity has become a critical concern for developers and organizations. This comprehensive guide covers essential security practices for modern application development. We begin by examining the current threat landscape and common attack vectors, including OWASP Top 10 vulnerabilities. The article covers authentication and authorization best practices, including multi-factor authentication, OAuth 2.0, and role-based access control. We explore data protection strategies, including encryption at rest and in transit, secure key management, and data anonymization techniques. The guide addresses application security testing, including static analysis, dynamic testing, and penetration testing. We examine secure coding practices for different programming languages and frameworks. The article also covers infrastructure security, including container security, network segmentation, and cloud security best practices. We discuss incident response planning and security monitoring strategies. The guide concludes with compliance considerations, including GDPR, HIPAA, and SOC 2 requirements, and practical steps for implementing a security-first development culture.'),
     
-    ('article-109', 'author-9', 'Performance Optimization: Techniques for High-Performance Applications', 'Building high-performance applications requires a deep understanding of system bottlenecks and optimization techniques. This comprehensive guide covers performance optimization strategies across the entire application stack. We begin by examining performance measurement and profiling techniques, including benchmarking, profiling tools, and performance monitoring. The article covers database optimization strategies, including query optimization, indexing, and connection pooling. We explore caching strategies at different levels, from application-level caching to CDN optimization. The guide addresses frontend performance optimization, including code splitting, lazy loading, and image optimization. We examine backend optimization techniques, including asynchronous processing, connection pooling, and load balancing. The article also covers infrastructure optimization, including server configuration, network optimization, and cloud resource management. We discuss performance testing strategies and tools for identifying bottlenecks. The guide concludes with best practices for maintaining performance over time and scaling applications effectively.'),
+    ('article-109', 'author-9', 'Performance Optimization: Techniques for High-Performance Applications', 'performance-optimization-techniques-for-high-performance-applications', 'Building high-performance applications requires a deep understanding of system bottlenecks and optimization techniques. This comprehensive guide covers performance optimization strategies across the entire application stack. We begin by examining performance measurement and profiling techniques, including benchmarking, profiling tools, and performance monitoring. The article covers database optimization strategies, including query optimization, indexing, and connection pooling. We explore caching strategies at different levels, from application-level caching to CDN optimization. The guide addresses frontend performance optimization, including code splitting, lazy loading, and image optimization. We examine backend optimization techniques, including asynchronous processing, connection pooling, and load balancing. The article also covers infrastructure optimization, including server configuration, network optimization, and cloud resource management. We discuss performance testing strategies and tools for identifying bottlenecks. The guide concludes with best practices for maintaining performance over time and scaling applications effectively.'),
     
-    ('article-110', 'author-10', 'Mobile App Development: Cross-Platform vs Native Approaches', 'Choosing the right approach for mobile app development is crucial for project success. This comprehensive guide compares native, cross-platform, and hybrid development approaches, helping teams make informed decisions. We begin by examining the native development approach, exploring platform-specific technologies like Swift for iOS and Kotlin for Android. The article covers cross-platform frameworks including React Native, Flutter, and Xamarin, comparing their strengths and limitations. We explore hybrid approaches using technologies like Cordova and Ionic. The guide addresses important considerations such as performance, user experience, development time, and maintenance costs. We examine platform-specific features and how to access them from different development approaches. The article also covers testing strategies for mobile applications, including unit testing, integration testing, and device testing. We discuss deployment and distribution strategies for both app stores and enterprise environments. The guide concludes with practical recommendations for choosing the right approach based on project requirements and team capabilities.')
+    ('article-110', 'author-10', 'Mobile App Development: Cross-Platform vs Native Approaches', 'mobile-app-development-cross-platform-vs-native-approaches', 'Choosing the right approach for mobile app development is crucial for project success. This comprehensive guide compares native, cross-platform, and hybrid development approaches, helping teams make informed decisions. We begin by examining the native development approach, exploring platform-specific technologies like Swift for iOS and Kotlin for Android. The article covers cross-platform frameworks including React Native, Flutter, and Xamarin, comparing their strengths and limitations. We explore hybrid approaches using technologies like Cordova and Ionic. The guide addresses important considerations such as performance, user experience, development time, and maintenance costs. We examine platform-specific features and how to access them from different development approaches. The article also covers testing strategies for mobile applications, including unit testing, integration testing, and device testing. We discuss deployment and distribution strategies for both app stores and enterprise environments. The guide concludes with practical recommendations for choosing the right approach based on project requirements and team capabilities.')
 ON CONFLICT (id) DO NOTHING;